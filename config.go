@@ -22,6 +22,7 @@ type DropboxConfig struct {
 	Hosts struct {
 		Api     string `json:"api"`
 		Content string `json:"content"`
+		Notify  string `json:"notify"`
 	} `json:"hosts"`
 }
 
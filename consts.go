@@ -1,6 +1,31 @@
 package dropbox
 
+import "time"
+
 const (
 	defaultPath = "."
 	path_key    = "path"
+
+	// defaultChunkSize is the size of each chunk sent on an upload session
+	defaultChunkSize = 8 * 1024 * 1024
+	// maxUploadSize is the biggest file accepted by a single upload request
+	maxUploadSize = 150 * 1024 * 1024
+
+	// defaultNotifyHost is used for long polling when the notify host is not configured
+	defaultNotifyHost = "https://notify.dropboxapi.com/2"
+	// longpollTimeout is the time, in seconds, that a long poll request waits for changes
+	longpollTimeout = 30
+	// watchRetryDelay is the time waited before retrying after an error while watching
+	watchRetryDelay = 5 * time.Second
+	// maxBatchEntries is the biggest number of entries accepted by a single batch request
+	maxBatchEntries = 1000
+	// maxThumbnailBatchEntries is the biggest number of entries accepted by a single thumbnail batch request
+	maxThumbnailBatchEntries = 25
+	// jobPollInterval is the time waited between checks of the status of an async job
+	jobPollInterval = time.Second
+	// temporaryLinkDuration is the time that a temporary download link is valid
+	temporaryLinkDuration = 4 * time.Hour
+	// minTemporaryUploadLinkDuration and maxTemporaryUploadLinkDuration are the limits of the duration of a temporary upload link
+	minTemporaryUploadLinkDuration = time.Minute
+	maxTemporaryUploadLinkDuration = 4 * time.Hour
 )
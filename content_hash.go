@@ -0,0 +1,141 @@
+package dropbox
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+const (
+	// ContentHashBlockSize is the size of the blocks hashed individually by the dropbox content hash
+	ContentHashBlockSize = 4 * 1024 * 1024
+)
+
+// ContentHash computes the dropbox content hash of a file, the sha256 of the concatenated sha256 of each 4 MB block
+type ContentHash struct {
+	overall   hash.Hash
+	block     hash.Hash
+	blockSize int
+}
+
+// NewContentHash ...
+func NewContentHash() *ContentHash {
+	return &ContentHash{
+		overall: sha256.New(),
+		block:   sha256.New(),
+	}
+}
+
+// ComputeContentHash computes the hex encoded content hash of the content of the reader, as returned by the api
+func ComputeContentHash(reader io.Reader) (string, error) {
+	hash := NewContentHash()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
+// Write ...
+func (h *ContentHash) Write(data []byte) (int, error) {
+	written := len(data)
+
+	for len(data) > 0 {
+		size := ContentHashBlockSize - h.blockSize
+		if size > len(data) {
+			size = len(data)
+		}
+
+		h.block.Write(data[:size])
+		h.blockSize += size
+		data = data[size:]
+
+		if h.blockSize == ContentHashBlockSize {
+			h.overall.Write(h.block.Sum(nil))
+			h.block.Reset()
+			h.blockSize = 0
+		}
+	}
+
+	return written, nil
+}
+
+// Sum ...
+func (h *ContentHash) Sum(b []byte) []byte {
+	if h.blockSize == 0 {
+		return h.overall.Sum(b)
+	}
+
+	// the pending block is added to a copy of the overall hash, to keep the current state
+	state, err := h.overall.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	overall := sha256.New()
+	if err := overall.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		panic(err)
+	}
+	overall.Write(h.block.Sum(nil))
+
+	return overall.Sum(b)
+}
+
+// String returns the hex encoded hash, as returned by the api
+func (h *ContentHash) String() string {
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Reset ...
+func (h *ContentHash) Reset() {
+	h.overall.Reset()
+	h.block.Reset()
+	h.blockSize = 0
+}
+
+// Size ...
+func (h *ContentHash) Size() int {
+	return sha256.Size
+}
+
+// BlockSize ...
+func (h *ContentHash) BlockSize() int {
+	return sha256.BlockSize
+}
+
+// verifyingReader validates the content hash of the content read, returning an IntegrityError at the end when it does not match
+type verifyingReader struct {
+	io.ReadCloser
+	hash     *ContentHash
+	path     string
+	expected string
+}
+
+func newVerifyingReader(reader io.ReadCloser, path, expected string) io.ReadCloser {
+	if expected == "" {
+		return reader
+	}
+
+	return &verifyingReader{
+		ReadCloser: reader,
+		hash:       NewContentHash(),
+		path:       path,
+		expected:   expected,
+	}
+}
+
+// Read ...
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+
+	if err == io.EOF {
+		if actual := r.hash.String(); actual != r.expected {
+			return n, &IntegrityError{Path: r.path, Expected: r.expected, Actual: actual}
+		}
+	}
+
+	return n, err
+}
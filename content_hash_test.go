@@ -0,0 +1,168 @@
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var contentHashTests = []struct {
+	name     string
+	content  []byte
+	expected string
+}{
+	{
+		name:     "empty",
+		content:  []byte{},
+		expected: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	},
+	{
+		name:     "hello world",
+		content:  []byte("hello world"),
+		expected: "bc62d4b80d9e36da29c16c5d4d9f11731f36052c72401a76c23c0fb5a9b74423",
+	},
+	{
+		name:     "one block",
+		content:  bytes.Repeat([]byte("a"), ContentHashBlockSize),
+		expected: "907a506cf5e706bda5c7a29b43c9c65d8344bd2fa2f22339b359c214812af5a1",
+	},
+	{
+		name:     "one block and one byte",
+		content:  bytes.Repeat([]byte("a"), ContentHashBlockSize+1),
+		expected: "5f858b62ccd88447586305aec6fd53c96747cfebf527cbba129a6dfed47d9624",
+	},
+}
+
+func TestComputeContentHash(t *testing.T) {
+	for _, test := range contentHashTests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := ComputeContentHash(bytes.NewReader(test.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if actual != test.expected {
+				t.Errorf("content hash %s instead of %s", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestContentHashSplitWrites(t *testing.T) {
+	// the writes are split to cross the block boundaries in the middle of a write
+	sizes := []int{1, 1000, ContentHashBlockSize - 1, 7}
+
+	for _, test := range contentHashTests {
+		t.Run(test.name, func(t *testing.T) {
+			hash := NewContentHash()
+			content := test.content
+			for i := 0; len(content) > 0; i++ {
+				size := sizes[i%len(sizes)]
+				if size > len(content) {
+					size = len(content)
+				}
+				hash.Write(content[:size])
+				content = content[size:]
+			}
+
+			if actual := hash.String(); actual != test.expected {
+				t.Errorf("content hash %s instead of %s", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestDownloadStreamIntegrityError(t *testing.T) {
+	expected, _ := ComputeContentHash(strings.NewReader("hello world"))
+
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, _ := json.Marshal(downloadFileResponse{PathLower: "/file.txt", Size: 11, ContentHash: expected})
+		w.Header().Set("Dropbox-API-Result", string(result))
+		w.Write([]byte("hello w0rld"))
+	}))
+
+	stream, _, err := service.File().DownloadStream(context.Background(), "/file.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer stream.Close()
+
+	_, err = ioutil.ReadAll(stream)
+
+	var integrityError *IntegrityError
+	if !errors.As(err, &integrityError) {
+		t.Fatalf("error %v instead of an integrity error", err)
+	}
+	if integrityError.Expected != expected {
+		t.Errorf("expected content hash %s instead of %s", integrityError.Expected, expected)
+	}
+}
+
+func TestUploadIntegrityError(t *testing.T) {
+	// the server stores a corrupted body, with a content hash different from the one sent
+	expected, _ := ComputeContentHash(strings.NewReader("hello w0rld"))
+
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(uploadFileResponse{PathLower: "/file.txt", Size: 11, ContentHash: expected})
+	}))
+
+	_, err := service.File().Upload("/file.txt", []byte("hello world"))
+
+	var integrityError *IntegrityError
+	if !errors.As(err, &integrityError) {
+		t.Fatalf("error %v instead of an integrity error", err)
+	}
+	if integrityError.Expected != expected {
+		t.Errorf("expected content hash %s instead of %s", integrityError.Expected, expected)
+	}
+}
+
+func TestDownloadIntegrityError(t *testing.T) {
+	expected, _ := ComputeContentHash(strings.NewReader("hello world"))
+
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{name: "valid body", content: "hello world", valid: true},
+		{name: "corrupted body", content: "hello w0rld", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/files/get_metadata":
+					json.NewEncoder(w).Encode(metadata{Tag: metadataTagFile, PathLower: "/file.txt", Rev: "rev1", ContentHash: expected})
+				case "/files/download":
+					// the download is pinned to the revision of the metadata, and sends no Dropbox-API-Result header
+					request := downloadFileRequest{}
+					json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &request)
+					if request.Path != "rev:rev1" {
+						w.WriteHeader(http.StatusConflict)
+						return
+					}
+					w.Write([]byte(test.content))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			content, err := service.File().Download("/file.txt")
+
+			var integrityError *IntegrityError
+			if test.valid && (err != nil || string(content) != test.content) {
+				t.Errorf("content %q and error %v instead of %q", content, err, test.content)
+			}
+			if !test.valid && !errors.As(err, &integrityError) {
+				t.Errorf("error %v instead of an integrity error", err)
+			}
+		})
+	}
+}
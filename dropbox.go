@@ -1,21 +1,26 @@
 package dropbox
 
 import (
+	"net/http"
+
 	"github.com/joaosoft/logger"
 	"github.com/joaosoft/manager"
 )
 
 type Dropbox struct {
 	client        manager.IGateway
+	httpClient    *http.Client
 	config        *DropboxConfig
 	pm            *manager.Manager
 	logger        logger.ILogger
 	isLogExternal bool
+	chunkSize     int
 
 	// usage ...
-	user   *User
-	folder *Folder
-	file   *File
+	user    *User
+	folder  *Folder
+	file    *File
+	sharing *Sharing
 }
 
 // NewDropbox ...
@@ -29,10 +34,12 @@ func NewDropbox(options ...DropboxOption) (*Dropbox, error) {
 	}
 
 	service := &Dropbox{
-		client: client,
-		pm:     pm,
-		config: config.Dropbox,
-		logger: logger.NewLogDefault("dropbox", logger.WarnLevel),
+		client:     client,
+		httpClient: &http.Client{},
+		pm:         pm,
+		config:     config.Dropbox,
+		logger:     logger.NewLogDefault("dropbox", logger.WarnLevel),
+		chunkSize:  defaultChunkSize,
 	}
 
 	if service.isLogExternal {
@@ -69,9 +76,10 @@ func (d *Dropbox) User() *User {
 func (d *Dropbox) Folder() *Folder {
 	if d.folder == nil {
 		d.folder = &Folder{
-			client: d.client,
-			config: d.config,
-			logger: d.logger,
+			client:     d.client,
+			httpClient: d.httpClient,
+			config:     d.config,
+			logger:     d.logger,
 		}
 	}
 	return d.folder
@@ -81,10 +89,25 @@ func (d *Dropbox) Folder() *Folder {
 func (d *Dropbox) File() *File {
 	if d.file == nil {
 		d.file = &File{
-			client: d.client,
-			config: d.config,
-			logger: d.logger,
+			client:     d.client,
+			httpClient: d.httpClient,
+			config:     d.config,
+			logger:     d.logger,
+			chunkSize:  d.chunkSize,
 		}
 	}
 	return d.file
 }
+
+// Sharing ...
+func (d *Dropbox) Sharing() *Sharing {
+	if d.sharing == nil {
+		d.sharing = &Sharing{
+			client:     d.client,
+			httpClient: d.httpClient,
+			config:     d.config,
+			logger:     d.logger,
+		}
+	}
+	return d.sharing
+}
@@ -0,0 +1,51 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/joaosoft/web"
+)
+
+// contentRequest executes a streaming request to the content host, with the arguments sent on the Dropbox-API-Arg header.
+// the caller is responsible for closing the body of the returned response
+func contentRequest(ctx context.Context, client *http.Client, config *DropboxConfig, endpoint string, args interface{}, body io.Reader, size int64, headers http.Header) (*http.Response, error) {
+	bodyArgs, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, config.Hosts.Content+endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range headers {
+		request.Header[key] = values
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("%s %s", config.Authorization.Access, config.Authorization.Token))
+	request.Header.Set("Dropbox-API-Arg", string(bodyArgs))
+	if body != nil {
+		request.Header.Set("Content-Type", string(web.ContentTypeApplicationOctetStream))
+		if size >= 0 {
+			request.ContentLength = size
+		}
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		defer response.Body.Close()
+		message, _ := ioutil.ReadAll(response.Body)
+		return nil, newApiError(response.StatusCode, message)
+	}
+
+	return response, nil
+}
@@ -1,9 +1,14 @@
 package dropbox
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/joaosoft/logger"
@@ -15,21 +20,66 @@ type writeMode string
 
 const (
 	writeModeAdd       writeMode = "add"
-	writeModeOverwrite           = "overwrite"
+	writeModeOverwrite writeMode = "overwrite"
+	writeModeUpdate    writeMode = "update"
 )
 
+// writeModeArg is the write mode of an upload, with the revision expected to be on the server on the update mode
+type writeModeArg struct {
+	Mode writeMode
+	Rev  string
+}
+
+// MarshalJSON ...
+func (w writeModeArg) MarshalJSON() ([]byte, error) {
+	if w.Mode == writeModeUpdate {
+		return json.Marshal(struct {
+			Tag    writeMode `json:".tag"`
+			Update string    `json:"update"`
+		}{Tag: w.Mode, Update: w.Rev})
+	}
+
+	return json.Marshal(struct {
+		Tag writeMode `json:".tag"`
+	}{Tag: w.Mode})
+}
+
 type File struct {
-	client manager.IGateway
-	config *DropboxConfig
-	logger logger.ILogger
+	client     manager.IGateway
+	httpClient *http.Client
+	config     *DropboxConfig
+	logger     logger.ILogger
+	chunkSize  int
 }
 
 type uploadFileRequest struct {
-	Path           string    `json:"path"`
-	Mode           writeMode `json:"mode"`
-	AutoRename     bool      `json:"autorename"`
-	Mute           bool      `json:"mute"`
-	StrictConflict bool      `json:"strict_conflict"`
+	Path           string       `json:"path"`
+	Mode           writeModeArg `json:"mode"`
+	AutoRename     bool         `json:"autorename"`
+	ClientModified *time.Time   `json:"client_modified,omitempty"`
+	Mute           bool         `json:"mute"`
+	StrictConflict bool         `json:"strict_conflict"`
+}
+
+// newUploadFileRequest creates the arguments of an upload with the options, failing on the update write mode without revision
+func (f *File) newUploadFileRequest(path string, options ...UploadOption) (uploadFileRequest, error) {
+	request := uploadFileRequest{
+		Path:       path,
+		Mode:       writeModeArg{Mode: writeModeOverwrite},
+		AutoRename: true,
+		Mute:       false,
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
+
+	if request.Mode.Mode == writeModeUpdate && request.Mode.Rev == "" {
+		err := f.logger.Errorf("the revision is required to upload %s on the update write mode", path).ToError()
+		return request, err
+	}
+
+	return request, nil
 }
 
 type uploadFileResponse struct {
@@ -57,14 +107,13 @@ type uploadFileResponse struct {
 	ContentHash              string `json:"content_hash"`
 }
 
-func (f *File) Upload(path string, file []byte) (*uploadFileResponse, error) {
-	var err error
+// Upload uploads the file to the path. when the write mode is update and the revision on the server is different, the error matches ErrConflict.
+// the content hash of the uploaded file is validated, failing with an IntegrityError when it does not match
+func (f *File) Upload(path string, file []byte, options ...UploadOption) (*uploadFileResponse, error) {
 	var bodyArgs []byte
-	args := uploadFileRequest{
-		Path:       path,
-		Mode:       writeModeOverwrite,
-		AutoRename: true,
-		Mute:       false,
+	args, err := f.newUploadFileRequest(path, options...)
+	if err != nil {
+		return nil, err
 	}
 
 	if bodyArgs, err = json.Marshal(args); err != nil {
@@ -86,9 +135,8 @@ func (f *File) Upload(path string, file []byte) (*uploadFileResponse, error) {
 		f.logger.WithField("response", response).Errorf("error uploading file to %s", path)
 		return nil, err
 	} else if status != http.StatusOK {
-		var err error
-		err = f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK).ToError()
-		return nil, err
+		f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return nil, newApiError(status, response)
 	} else if response == nil {
 		err = f.logger.WithField("response", response).Errorf("error uploading file to %s", path).ToError()
 		return nil, err
@@ -97,24 +145,83 @@ func (f *File) Upload(path string, file []byte) (*uploadFileResponse, error) {
 			err = f.logger.Error("errors converting Img response data").ToError()
 			return nil, err
 		}
+
+		hash := NewContentHash()
+		hash.Write(file)
+		if err := f.verifyContentHash(path, hash.String(), dropboxResponse.ContentHash); err != nil {
+			return nil, err
+		}
 		return dropboxResponse, nil
 	}
+}
+
+// UploadReader streams the content of the reader to the given path, without loading it in memory.
+// when the size is unknown (negative) or bigger than the single upload limit, it falls back to an upload session
+func (f *File) UploadReader(ctx context.Context, path string, reader io.Reader, size int64, options ...UploadOption) (*uploadFileResponse, error) {
+	if size < 0 || size > maxUploadSize {
+		return f.uploadSession(ctx, path, reader, options...)
+	}
 
-	return nil, nil
+	args, err := f.newUploadFileRequest(path, options...)
+	if err != nil {
+		return nil, err
+	}
+	hash := NewContentHash()
+
+	response, err := contentRequest(ctx, f.httpClient, f.config, "/files/upload", args, io.TeeReader(io.LimitReader(reader, size), hash), size, nil)
+	if err != nil {
+		f.logger.WithField("error", err).Errorf("error uploading file to %s", path)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	dropboxResponse := &uploadFileResponse{}
+	if err := json.NewDecoder(response.Body).Decode(dropboxResponse); err != nil {
+		err = f.logger.Error("errors converting upload response data").ToError()
+		return nil, err
+	}
+
+	if err := f.verifyContentHash(path, hash.String(), dropboxResponse.ContentHash); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// verifyContentHash validates the content hash computed locally against the one of the server, when the server has one
+func (f *File) verifyContentHash(path, actual, expected string) error {
+	if expected == "" || actual == expected {
+		return nil
+	}
+
+	f.logger.Errorf("content hash %s of %s does not match %s", actual, path, expected)
+	return &IntegrityError{Path: path, Expected: expected, Actual: actual}
 }
 
 type downloadFileRequest struct {
 	Path string `json:"path"`
 }
 
+// Download downloads the file, failing with an IntegrityError when its content hash does not match the one of the server.
+// since the gateway does not return the response headers, the revision and content hash are requested first, and the
+// download is pinned to that revision. a path that is already a revision, like rev:<rev>, is downloaded without validation
 func (f *File) Download(path string) ([]byte, error) {
-	var err error
-	var bodyArgs []byte
-	args := downloadFileRequest{
-		Path: path,
+	source := path
+	var expected string
+	if !strings.HasPrefix(path, "rev:") {
+		fileMetadata, err := f.GetMetadata(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if fileMetadata.Rev != "" {
+			source = "rev:" + fileMetadata.Rev
+			expected = fileMetadata.ContentHash
+		}
 	}
 
-	if bodyArgs, err = json.Marshal(args); err != nil {
+	bodyArgs, err := json.Marshal(downloadFileRequest{Path: source})
+	if err != nil {
 		err = f.logger.Error("errors converting download input arguments").ToError()
 		return nil, err
 	}
@@ -128,16 +235,269 @@ func (f *File) Download(path string) ([]byte, error) {
 		err = f.logger.WithField("response", response).Error("errors downloading File").ToError()
 		return nil, err
 	} else if status != http.StatusOK {
-		err = f.logger.WithField("response", response).WithFields(map[string]interface{}{"response": string(response)}).Errorf("response status %d instead of %d", status, http.StatusOK).ToError()
-		return nil, err
+		f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return nil, newApiError(status, response)
 	} else if response == nil {
 		err = f.logger.Error("errors downloading File").ToError()
 		return nil, err
 	} else {
+		hash := NewContentHash()
+		hash.Write(response)
+		if err := f.verifyContentHash(path, hash.String(), expected); err != nil {
+			return nil, err
+		}
 		return response, nil
 	}
+}
+
+type downloadFileResponse struct {
+	Name           string    `json:"name"`
+	ID             string    `json:"id"`
+	ClientModified time.Time `json:"client_modified"`
+	ServerModified time.Time `json:"server_modified"`
+	Rev            string    `json:"rev"`
+	Size           int       `json:"size"`
+	PathLower      string    `json:"path_lower"`
+	PathDisplay    string    `json:"path_display"`
+	SharingInfo    struct {
+		ReadOnly             bool   `json:"read_only"`
+		ParentSharedFolderID string `json:"parent_shared_folder_id"`
+		ModifiedBy           string `json:"modified_by"`
+	} `json:"sharing_info"`
+	HasExplicitSharedMembers bool   `json:"has_explicit_shared_members"`
+	ContentHash              string `json:"content_hash"`
+}
+
+// DownloadStream downloads the file as a stream, together with the metadata sent on the Dropbox-API-Result header.
+// the stream validates the content hash, failing the last read with an IntegrityError when it does not match.
+// the caller is responsible for closing the returned stream
+func (f *File) DownloadStream(ctx context.Context, path string) (io.ReadCloser, *downloadFileResponse, error) {
+	response, dropboxResponse, err := f.download(ctx, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newVerifyingReader(response.Body, path, dropboxResponse.ContentHash), dropboxResponse, nil
+}
+
+// DownloadRange downloads a range of the file as a stream, starting at the offset and with the given length.
+// when the length is not positive, the file is downloaded until the end
+func (f *File) DownloadRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, *downloadFileResponse, error) {
+	byteRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	response, dropboxResponse, err := f.download(ctx, path, http.Header{"Range": {byteRange}})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response.Body, dropboxResponse, nil
+}
+
+// DownloadResume downloads the file to the local file, continuing from the length it already has.
+// the download continues on the revision the file has on the server, and restarts from the beginning when the local
+// content does not belong to that revision. the local file is validated with the content hash when the download finishes
+func (f *File) DownloadResume(ctx context.Context, path string, localFile string) (*downloadFileResponse, error) {
+	file, err := os.OpenFile(localFile, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		err = f.logger.WithField("error", err).Errorf("error opening local file %s", localFile).ToError()
+		return nil, err
+	}
+	defer file.Close()
+
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		err = f.logger.WithField("error", err).Errorf("error seeking local file %s", localFile).ToError()
+		return nil, err
+	}
+
+	// with a partial local file, the metadata is requested first to pin the revision and know how much is missing
+	source := path
+	var rev string
+	if offset > 0 {
+		fileMetadata, err := f.GetMetadata(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if fileMetadata.Rev != "" {
+			rev = fileMetadata.Rev
+			source = "rev:" + rev
+		}
+
+		switch size := int64(fileMetadata.Size); {
+		case size < offset:
+			offset = 0
+		case size == offset:
+			// the last byte is downloaded again, since a range starting at the end of the file is not satisfiable
+			offset--
+		}
+	}
+
+	dropboxResponse, err := f.downloadFrom(ctx, file, localFile, source, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > 0 {
+		err = f.verifyLocalFile(file, localFile, dropboxResponse.ContentHash)
+		if err == nil && (rev == "" || dropboxResponse.Rev == rev) {
+			return dropboxResponse, nil
+		}
+
+		var integrityError *IntegrityError
+		if err != nil && !errors.As(err, &integrityError) {
+			return nil, err
+		}
+
+		// the local content is from another revision, so the whole file is downloaded again
+		f.logger.Warnf("local file %s can not be resumed from %s, restarting the download", localFile, path)
+		if dropboxResponse, err = f.downloadFrom(ctx, file, localFile, source, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = f.verifyLocalFile(file, localFile, dropboxResponse.ContentHash); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// downloadFrom downloads the file to the local file from the offset, replacing the local content after it
+func (f *File) downloadFrom(ctx context.Context, file *os.File, localFile, path string, offset int64) (*downloadFileResponse, error) {
+	var headers http.Header
+	if offset > 0 {
+		headers = http.Header{"Range": {fmt.Sprintf("bytes=%d-", offset)}}
+	}
+
+	response, dropboxResponse, err := f.download(ctx, path, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// the server sends the whole file when it does not honour the range
+	if offset > 0 && response.StatusCode != http.StatusPartialContent {
+		offset = 0
+	}
+
+	if err = file.Truncate(offset); err != nil {
+		err = f.logger.WithField("error", err).Errorf("error truncating local file %s", localFile).ToError()
+		return nil, err
+	}
 
-	return nil, nil
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		err = f.logger.WithField("error", err).Errorf("error seeking local file %s", localFile).ToError()
+		return nil, err
+	}
+
+	if _, err = io.Copy(file, response.Body); err != nil {
+		err = f.logger.WithField("error", err).Errorf("error downloading file from %s", path).ToError()
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// verifyLocalFile validates the content hash of the local file against the one of the server
+func (f *File) verifyLocalFile(file *os.File, localFile, expected string) error {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		err = f.logger.WithField("error", err).Errorf("error seeking local file %s", localFile).ToError()
+		return err
+	}
+
+	contentHash, err := ComputeContentHash(file)
+	if err != nil {
+		err = f.logger.WithField("error", err).Errorf("error reading local file %s", localFile).ToError()
+		return err
+	}
+
+	return f.verifyContentHash(localFile, contentHash, expected)
+}
+
+func (f *File) download(ctx context.Context, path string, headers http.Header) (*http.Response, *downloadFileResponse, error) {
+	args := downloadFileRequest{
+		Path: path,
+	}
+
+	response, err := contentRequest(ctx, f.httpClient, f.config, "/files/download", args, nil, 0, headers)
+	if err != nil {
+		f.logger.WithField("error", err).Errorf("error downloading file from %s", path)
+		return nil, nil, err
+	}
+
+	dropboxResponse := &downloadFileResponse{}
+	if err := json.Unmarshal([]byte(response.Header.Get("Dropbox-API-Result")), dropboxResponse); err != nil {
+		response.Body.Close()
+		err = f.logger.Error("errors converting download response metadata").ToError()
+		return nil, nil, err
+	}
+
+	return response, dropboxResponse, nil
+}
+
+type getMetadataRequest struct {
+	Path                            string `json:"path"`
+	IncludeMediaInfo                bool   `json:"include_media_info"`
+	IncludeDeleted                  bool   `json:"include_deleted"`
+	IncludeHasExplicitSharedMembers bool   `json:"include_has_explicit_shared_members"`
+}
+
+// GetMetadata gets the metadata of a file or folder. when the path does not exist, the error matches ErrNotFound
+func (f *File) GetMetadata(path string, options ...GetMetadataOption) (*metadata, error) {
+	request := getMetadataRequest{
+		Path:                            path,
+		IncludeMediaInfo:                false,
+		IncludeDeleted:                  false,
+		IncludeHasExplicitSharedMembers: false,
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		err = f.logger.Error("errors marshal arguments").ToError()
+		return nil, err
+	}
+
+	headers := manager.Headers{
+		"Authorization": {fmt.Sprintf("%s %s", f.config.Authorization.Access, f.config.Authorization.Token)},
+	}
+
+	dropboxResponse := &metadata{}
+	if status, response, err := f.client.Request(http.MethodPost, f.config.Hosts.Api, "/files/get_metadata", string(web.ContentTypeApplicationJSON), headers, body); err != nil {
+		err = f.logger.WithField("response", response).Error("errors getting metadata").ToError()
+		return nil, err
+	} else if status != http.StatusOK {
+		f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return nil, newApiError(status, response)
+	} else if response == nil {
+		err = f.logger.Error("errors getting metadata").ToError()
+		return nil, err
+	} else {
+		if err := json.Unmarshal(response, dropboxResponse); err != nil {
+			err = f.logger.Error("errors converting metadata response data").ToError()
+			return nil, err
+		}
+		return dropboxResponse, nil
+	}
+}
+
+// Exists checks if the file or folder exists
+func (f *File) Exists(path string) (bool, error) {
+	if _, err := f.GetMetadata(path); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
 }
 
 type deleteFileRequest struct {
@@ -205,6 +565,104 @@ func (f *File) Delete(path string) (*deleteFileResponse, error) {
 		}
 		return dropboxResponse, nil
 	}
+}
+
+type relocationRequest struct {
+	FromPath               string `json:"from_path"`
+	ToPath                 string `json:"to_path"`
+	AllowSharedFolder      bool   `json:"allow_shared_folder"`
+	AutoRename             bool   `json:"autorename"`
+	AllowOwnershipTransfer bool   `json:"allow_ownership_transfer"`
+}
+
+type relocationResponse struct {
+	Metadata metadata `json:"metadata"`
+}
+
+// Move moves a file or folder to a new path, returning the metadata at the new path
+func (f *File) Move(from, to string, options ...RelocationOption) (*relocationResponse, error) {
+	return f.relocate("/files/move_v2", newRelocationRequest(from, to, options...))
+}
+
+// Copy copies a file or folder to a new path, returning the metadata of the copy
+func (f *File) Copy(from, to string, options ...RelocationOption) (*relocationResponse, error) {
+	return f.relocate("/files/copy_v2", newRelocationRequest(from, to, options...))
+}
+
+func newRelocationRequest(from, to string, options ...RelocationOption) relocationRequest {
+	request := relocationRequest{
+		FromPath:               from,
+		ToPath:                 to,
+		AllowSharedFolder:      false,
+		AutoRename:             false,
+		AllowOwnershipTransfer: false,
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
 
-	return nil, nil
+	return request
+}
+
+func (f *File) relocate(endpoint string, request relocationRequest) (*relocationResponse, error) {
+	dropboxResponse := &relocationResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, endpoint, request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+type getCopyReferenceRequest struct {
+	Path string `json:"path"`
+}
+
+type getCopyReferenceResponse struct {
+	Metadata      metadata  `json:"metadata"`
+	CopyReference string    `json:"copy_reference"`
+	Expires       time.Time `json:"expires"`
+}
+
+// GetCopyReference gets a copy reference to a file or folder, that can be saved into another account with SaveCopyReference
+func (f *File) GetCopyReference(path string) (*getCopyReferenceResponse, error) {
+	dropboxResponse := &getCopyReferenceResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/copy_reference/get", getCopyReferenceRequest{Path: path}, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+type saveCopyReferenceRequest struct {
+	CopyReference string `json:"copy_reference"`
+	Path          string `json:"path"`
+}
+
+type saveCopyReferenceResponse struct {
+	Metadata metadata `json:"metadata"`
+}
+
+// SaveCopyReference saves a copy reference, obtained with GetCopyReference, to the given path
+func (f *File) SaveCopyReference(copyReference, path string) (*saveCopyReferenceResponse, error) {
+	request := saveCopyReferenceRequest{
+		CopyReference: copyReference,
+		Path:          path,
+	}
+
+	dropboxResponse := &saveCopyReferenceResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/copy_reference/save", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+func (f *File) folder() *Folder {
+	return &Folder{
+		client:     f.client,
+		httpClient: f.httpClient,
+		config:     f.config,
+		logger:     f.logger,
+	}
 }
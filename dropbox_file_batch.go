@@ -0,0 +1,183 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	batchTagSuccess = "success"
+	batchTagFailure = "failure"
+
+	// failureFolderConflict is the failure of creating a folder where there is already one
+	failureFolderConflict = "path/conflict/folder"
+)
+
+// RelocationPath is a pair of source and destination paths of a batch move or copy
+type RelocationPath struct {
+	FromPath string `json:"from_path"`
+	ToPath   string `json:"to_path"`
+}
+
+type batchEntryResult struct {
+	Path     string
+	Metadata *metadata
+	// Failure is the summary of the error when the entry failed, like path_lookup/not_found
+	Failure string
+}
+
+// Success ...
+func (r *batchEntryResult) Success() bool {
+	return r.Failure == ""
+}
+
+type batchEntry struct {
+	Tag      string          `json:".tag"`
+	Metadata *metadata       `json:"metadata,omitempty"`
+	Success  *metadata       `json:"success,omitempty"`
+	Failure  json.RawMessage `json:"failure,omitempty"`
+}
+
+type batchResponse struct {
+	Entries []batchEntry `json:"entries"`
+}
+
+type deleteBatchRequest struct {
+	Entries []deleteFileRequest `json:"entries"`
+}
+
+// DeleteBatch deletes multiple files or folders at once, waiting for the async job to finish.
+// the paths are sent in requests of up to 1000 entries, and the results are in the same order of the paths
+func (f *File) DeleteBatch(ctx context.Context, paths []string) ([]batchEntryResult, error) {
+	return f.batch(ctx, "/files/delete_batch", "/files/delete_batch/check", paths, func(start, end int) interface{} {
+		request := deleteBatchRequest{
+			Entries: make([]deleteFileRequest, 0, end-start),
+		}
+
+		for _, path := range paths[start:end] {
+			request.Entries = append(request.Entries, deleteFileRequest{Path: path})
+		}
+
+		return request
+	})
+}
+
+type relocationBatchRequest struct {
+	Entries                []RelocationPath `json:"entries"`
+	AutoRename             bool             `json:"autorename"`
+	AllowOwnershipTransfer bool             `json:"allow_ownership_transfer,omitempty"`
+}
+
+// MoveBatch moves multiple files or folders at once, waiting for the async job to finish.
+// the entries are sent in requests of up to 1000 entries, and the results are in the same order of the entries.
+// the option to allow shared folders is ignored, since shared folders are always moved by the batch move
+func (f *File) MoveBatch(ctx context.Context, entries []RelocationPath, options ...RelocationOption) ([]batchEntryResult, error) {
+	relocation := newRelocationRequest("", "", options...)
+
+	return f.batch(ctx, "/files/move_batch_v2", "/files/move_batch/check_v2", relocationPaths(entries), func(start, end int) interface{} {
+		return relocationBatchRequest{
+			Entries:                entries[start:end],
+			AutoRename:             relocation.AutoRename,
+			AllowOwnershipTransfer: relocation.AllowOwnershipTransfer,
+		}
+	})
+}
+
+// CopyBatch copies multiple files or folders at once, waiting for the async job to finish.
+// the entries are sent in requests of up to 1000 entries, and the results are in the same order of the entries.
+// only the auto rename option is used, the batch copy does not accept the other relocation options
+func (f *File) CopyBatch(ctx context.Context, entries []RelocationPath, options ...RelocationOption) ([]batchEntryResult, error) {
+	relocation := newRelocationRequest("", "", options...)
+
+	return f.batch(ctx, "/files/copy_batch_v2", "/files/copy_batch/check_v2", relocationPaths(entries), func(start, end int) interface{} {
+		return relocationBatchRequest{
+			Entries:    entries[start:end],
+			AutoRename: relocation.AutoRename,
+		}
+	})
+}
+
+// CheckJob polls the check endpoint of an async job until it is no longer in progress, decoding its final status into dropboxResponse
+func (f *File) CheckJob(ctx context.Context, checkEndpoint, asyncJobID string, dropboxResponse interface{}) error {
+	response, err := pollJob(ctx, f.client, f.config, f.logger, checkEndpoint, asyncJobID)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(response, dropboxResponse); err != nil {
+		err = f.logger.Error("errors converting async job response data").ToError()
+		return err
+	}
+
+	return nil
+}
+
+// batch executes the batch in requests of up to maxBatchEntries entries, with the request of each range of the paths
+func (f *File) batch(ctx context.Context, endpoint, checkEndpoint string, paths []string, newRequest func(start, end int) interface{}) ([]batchEntryResult, error) {
+	results := make([]batchEntryResult, 0, len(paths))
+
+	for start := 0; start < len(paths); start += maxBatchEntries {
+		end := start + maxBatchEntries
+		if end > len(paths) {
+			end = len(paths)
+		}
+
+		chunkResults, err := f.batchChunk(ctx, endpoint, checkEndpoint, newRequest(start, end), paths[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+func (f *File) batchChunk(ctx context.Context, endpoint, checkEndpoint string, request interface{}, paths []string) ([]batchEntryResult, error) {
+	var launch json.RawMessage
+	if err := rpcRequest(f.client, f.config, f.logger, endpoint, request, &launch); err != nil {
+		return nil, err
+	}
+
+	response, err := waitJob(ctx, f.client, f.config, f.logger, checkEndpoint, launch)
+	if err != nil {
+		return nil, err
+	}
+
+	dropboxResponse := &batchResponse{}
+	if err := json.Unmarshal(response, dropboxResponse); err != nil {
+		err = f.logger.Error("errors converting batch response data").ToError()
+		return nil, err
+	}
+
+	results := make([]batchEntryResult, len(dropboxResponse.Entries))
+	for i, entry := range dropboxResponse.Entries {
+		if i < len(paths) {
+			results[i].Path = paths[i]
+		}
+
+		switch entry.Tag {
+		case batchTagSuccess:
+			results[i].Metadata = entry.Metadata
+			if entry.Success != nil {
+				results[i].Metadata = entry.Success
+			}
+		case batchTagFailure:
+			if results[i].Failure = unionSummary(entry.Failure); results[i].Failure == "" {
+				results[i].Failure = batchTagFailure
+			}
+		default:
+			results[i].Failure = entry.Tag
+		}
+	}
+
+	return results, nil
+}
+
+func relocationPaths(entries []RelocationPath) []string {
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.FromPath
+	}
+
+	return paths
+}
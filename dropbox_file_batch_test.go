@@ -0,0 +1,90 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeBatchServer is a delete batch server that runs every batch as an async job, rejecting more than maxBatchEntries entries
+type fakeBatchServer struct {
+	jobs     map[string][]deleteFileRequest
+	requests int
+}
+
+func (s *fakeBatchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/files/delete_batch":
+		request := deleteBatchRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+		if len(request.Entries) > maxBatchEntries {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.requests++
+		asyncJobID := fmt.Sprintf("job-%d", s.requests)
+		s.jobs[asyncJobID] = request.Entries
+		fmt.Fprintf(w, `{".tag": "async_job_id", "async_job_id": %q}`, asyncJobID)
+
+	case "/files/delete_batch/check":
+		request := asyncJobRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		entries := make([]string, 0)
+		for _, entry := range s.jobs[request.AsyncJobID] {
+			if strings.Contains(entry.Path, "missing") {
+				entries = append(entries, `{".tag": "failure", "failure": {".tag": "path_lookup", "path_lookup": {".tag": "not_found"}}}`)
+			} else {
+				entries = append(entries, fmt.Sprintf(`{".tag": "success", "metadata": {".tag": "file", "path_lower": %q}}`, entry.Path))
+			}
+		}
+		fmt.Fprintf(w, `{".tag": "complete", "entries": [%s]}`, strings.Join(entries, ","))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestDeleteBatch(t *testing.T) {
+	paths := make([]string, 2500)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("/file-%d.txt", i)
+		if i%100 == 0 {
+			paths[i] = fmt.Sprintf("/missing-%d.txt", i)
+		}
+	}
+
+	server := &fakeBatchServer{jobs: make(map[string][]deleteFileRequest)}
+	service := newTestDropbox(t, server)
+
+	results, err := service.File().DeleteBatch(context.Background(), paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if server.requests != 3 {
+		t.Errorf("%d batch requests instead of 3", server.requests)
+	}
+
+	if len(results) != len(paths) {
+		t.Fatalf("%d results instead of %d", len(results), len(paths))
+	}
+
+	for i, result := range results {
+		if result.Path != paths[i] {
+			t.Fatalf("result %d of path %s instead of %s", i, result.Path, paths[i])
+		}
+
+		if strings.Contains(paths[i], "missing") {
+			if result.Failure != "path_lookup/not_found" {
+				t.Errorf("failure %q of %s instead of path_lookup/not_found", result.Failure, paths[i])
+			}
+		} else if !result.Success() || result.Metadata.PathLower != paths[i] {
+			t.Errorf("result of %s is not a success on the same path", paths[i])
+		}
+	}
+}
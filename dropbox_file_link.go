@@ -0,0 +1,72 @@
+package dropbox
+
+import (
+	"time"
+)
+
+type getTemporaryLinkRequest struct {
+	Path string `json:"path"`
+}
+
+type getTemporaryLinkResponse struct {
+	Metadata metadata `json:"metadata"`
+	Link     string   `json:"link"`
+	// Expires is when the link expires, computed from the time of the request
+	Expires time.Time `json:"-"`
+}
+
+// GetTemporaryLink gets a link to download the file directly from dropbox, that expires after four hours
+func (f *File) GetTemporaryLink(path string) (*getTemporaryLinkResponse, error) {
+	now := time.Now()
+
+	dropboxResponse := &getTemporaryLinkResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/get_temporary_link", getTemporaryLinkRequest{Path: path}, dropboxResponse); err != nil {
+		return nil, err
+	}
+	dropboxResponse.Expires = now.Add(temporaryLinkDuration)
+
+	return dropboxResponse, nil
+}
+
+type getTemporaryUploadLinkRequest struct {
+	CommitInfo uploadFileRequest `json:"commit_info"`
+	Duration   float64           `json:"duration"`
+}
+
+type getTemporaryUploadLinkResponse struct {
+	Link string `json:"link"`
+	// Expires is when the link expires, computed from the time of the request
+	Expires time.Time `json:"-"`
+	// CommitInfo is how the file uploaded to the link is committed
+	CommitInfo uploadFileRequest `json:"-"`
+}
+
+// GetTemporaryUploadLink gets a link to upload a file to the path directly to dropbox, with a post of the content.
+// the duration must be between one minute and four hours, otherwise it fails before any request
+func (f *File) GetTemporaryUploadLink(path string, duration time.Duration, options ...UploadOption) (*getTemporaryUploadLinkResponse, error) {
+	if duration < minTemporaryUploadLinkDuration || duration > maxTemporaryUploadLinkDuration {
+		err := f.logger.Errorf("duration %s of the temporary upload link to %s is not between %s and %s",
+			duration, path, minTemporaryUploadLinkDuration, maxTemporaryUploadLinkDuration).ToError()
+		return nil, err
+	}
+
+	commitInfo, err := f.newUploadFileRequest(path, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	request := getTemporaryUploadLinkRequest{
+		CommitInfo: commitInfo,
+		Duration:   duration.Seconds(),
+	}
+
+	dropboxResponse := &getTemporaryUploadLinkResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/get_temporary_upload_link", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+	dropboxResponse.Expires = now.Add(duration)
+	dropboxResponse.CommitInfo = request.CommitInfo
+
+	return dropboxResponse, nil
+}
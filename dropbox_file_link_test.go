@@ -0,0 +1,37 @@
+package dropbox
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetTemporaryUploadLinkDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		valid    bool
+	}{
+		{duration: 59 * time.Second, valid: false},
+		{duration: time.Minute, valid: true},
+		{duration: 4 * time.Hour, valid: true},
+		{duration: 4*time.Hour + time.Second, valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.duration.String(), func(t *testing.T) {
+			requests := 0
+			service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Write([]byte(`{"link": "https://content.dropboxapi.com/apitul/1/link"}`))
+			}))
+
+			_, err := service.File().GetTemporaryUploadLink("/file.txt", test.duration)
+			if test.valid && (err != nil || requests != 1) {
+				t.Errorf("error %v with %d requests on a valid duration", err, requests)
+			}
+			if !test.valid && (err == nil || requests != 0) {
+				t.Errorf("error %v with %d requests on an invalid duration", err, requests)
+			}
+		})
+	}
+}
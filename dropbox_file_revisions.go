@@ -0,0 +1,77 @@
+package dropbox
+
+import (
+	"time"
+)
+
+// ListRevisionsMode ...
+type ListRevisionsMode string
+
+const (
+	// ListRevisionsModePath lists the revisions of the path, even when the file was moved or deleted and recreated
+	ListRevisionsModePath ListRevisionsMode = "path"
+	// ListRevisionsModeID lists the revisions of the file with the id, following it when it is moved
+	ListRevisionsModeID ListRevisionsMode = "id"
+)
+
+type listRevisionsRequest struct {
+	Path  string `json:"path"`
+	Mode  tag    `json:"mode"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+type listRevisionsResponse struct {
+	IsDeleted     bool       `json:"is_deleted"`
+	ServerDeleted *time.Time `json:"server_deleted,omitempty"`
+	Entries       []metadata `json:"entries"`
+}
+
+// ListRevisions lists the revisions of a file, up to the limit when it is positive
+func (f *File) ListRevisions(path string, limit int, options ...ListRevisionsOption) (*listRevisionsResponse, error) {
+	request := listRevisionsRequest{
+		Path:  path,
+		Mode:  tag{Tag: string(ListRevisionsModePath)},
+		Limit: limit,
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
+
+	dropboxResponse := &listRevisionsResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/list_revisions", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+type restoreRequest struct {
+	Path string `json:"path"`
+	Rev  string `json:"rev"`
+}
+
+// Restore restores the file on the path to the given revision, returning its metadata
+func (f *File) Restore(path, rev string) (*metadata, error) {
+	request := restoreRequest{
+		Path: path,
+		Rev:  rev,
+	}
+
+	dropboxResponse := &metadata{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/restore", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// DownloadRev downloads a revision of a file
+func (f *File) DownloadRev(rev string) ([]byte, error) {
+	return f.Download(revPath(rev))
+}
+
+// revPath is the path used to access a revision of a file
+func revPath(rev string) string {
+	return "rev:" + rev
+}
@@ -0,0 +1,127 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type saveURLRequest struct {
+	Path string `json:"path"`
+	URL  string `json:"url"`
+}
+
+type saveURLResponse struct {
+	// AsyncJobID is the id of the job saving the url, to be checked with CheckSaveURLJob
+	AsyncJobID string
+	// Metadata is set when the url was saved right away
+	Metadata *metadata
+}
+
+// SaveURL saves the content of the url to the path, on an async job that runs on dropbox
+func (f *File) SaveURL(path, url string) (*saveURLResponse, error) {
+	request := saveURLRequest{
+		Path: path,
+		URL:  url,
+	}
+
+	var launch json.RawMessage
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/save_url", request, &launch); err != nil {
+		return nil, err
+	}
+
+	status := asyncJobStatus{}
+	if err := json.Unmarshal(launch, &status); err != nil {
+		err = f.logger.Error("errors converting save url response data").ToError()
+		return nil, err
+	}
+
+	dropboxResponse := &saveURLResponse{
+		AsyncJobID: status.AsyncJobID,
+	}
+
+	if status.Tag == jobTagComplete {
+		dropboxResponse.Metadata = &metadata{}
+		if err := json.Unmarshal(launch, dropboxResponse.Metadata); err != nil {
+			err = f.logger.Error("errors converting save url response data").ToError()
+			return nil, err
+		}
+		// the tag of the response is the status of the job, instead of the type of the metadata
+		dropboxResponse.Metadata.Tag = metadataTagFile
+	}
+
+	return dropboxResponse, nil
+}
+
+type saveURLJobStatusResponse struct {
+	// Tag is in_progress, complete or failed
+	Tag      string
+	Metadata *metadata
+	// Failure is the summary of the error when the job failed, like download_failed
+	Failure string
+}
+
+// CheckSaveURLJob checks the status of a job saving an url
+func (f *File) CheckSaveURLJob(asyncJobID string) (*saveURLJobStatusResponse, error) {
+	var response json.RawMessage
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/save_url/check_job_status", asyncJobRequest{AsyncJobID: asyncJobID}, &response); err != nil {
+		return nil, err
+	}
+
+	status := asyncJobStatus{}
+	if err := json.Unmarshal(response, &status); err != nil {
+		err = f.logger.Error("errors converting save url job response data").ToError()
+		return nil, err
+	}
+
+	dropboxResponse := &saveURLJobStatusResponse{
+		Tag: status.Tag,
+	}
+
+	switch status.Tag {
+	case jobTagComplete:
+		dropboxResponse.Metadata = &metadata{}
+		if err := json.Unmarshal(response, dropboxResponse.Metadata); err != nil {
+			err = f.logger.Error("errors converting save url job response data").ToError()
+			return nil, err
+		}
+		dropboxResponse.Metadata.Tag = metadataTagFile
+	case jobTagFailed:
+		dropboxResponse.Failure = unionSummary(status.Failed)
+	}
+
+	return dropboxResponse, nil
+}
+
+// SaveURLAndWait saves the content of the url to the path, waiting up to the timeout for the job to finish.
+// when the timeout expires, the error is a JobTimeoutError with the async job id, to keep checking it with CheckSaveURLJob
+func (f *File) SaveURLAndWait(path, url string, timeout time.Duration) (*metadata, error) {
+	dropboxResponse, err := f.SaveURL(path, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if dropboxResponse.Metadata != nil {
+		return dropboxResponse.Metadata, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	response, err := pollJob(ctx, f.client, f.config, f.logger, "/files/save_url/check_job_status", dropboxResponse.AsyncJobID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, &JobTimeoutError{AsyncJobID: dropboxResponse.AsyncJobID, Err: err}
+		}
+		return nil, err
+	}
+
+	saved := &metadata{}
+	if err := json.Unmarshal(response, saved); err != nil {
+		err = f.logger.Error("errors converting save url job response data").ToError()
+		return nil, err
+	}
+	saved.Tag = metadataTagFile
+
+	return saved, nil
+}
@@ -0,0 +1,33 @@
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSaveURLAndWaitTimeout(t *testing.T) {
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/save_url":
+			w.Write([]byte(`{".tag": "async_job_id", "async_job_id": "job-1"}`))
+		case "/files/save_url/check_job_status":
+			w.Write([]byte(`{".tag": "in_progress"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	_, err := service.File().SaveURLAndWait("/file.txt", "https://example.com/file.txt", 50*time.Millisecond)
+
+	var timeoutError *JobTimeoutError
+	if !errors.As(err, &timeoutError) || timeoutError.AsyncJobID != "job-1" {
+		t.Fatalf("error %v instead of a timeout of job-1", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error %v does not match the deadline exceeded", err)
+	}
+}
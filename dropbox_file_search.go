@@ -0,0 +1,191 @@
+package dropbox
+
+// SearchFileCategory ...
+type SearchFileCategory string
+
+const (
+	SearchFileCategoryImage        SearchFileCategory = "image"
+	SearchFileCategoryDocument     SearchFileCategory = "document"
+	SearchFileCategoryPdf          SearchFileCategory = "pdf"
+	SearchFileCategorySpreadsheet  SearchFileCategory = "spreadsheet"
+	SearchFileCategoryPresentation SearchFileCategory = "presentation"
+	SearchFileCategoryAudio        SearchFileCategory = "audio"
+	SearchFileCategoryVideo        SearchFileCategory = "video"
+	SearchFileCategoryFolder       SearchFileCategory = "folder"
+	SearchFileCategoryPaper        SearchFileCategory = "paper"
+	SearchFileCategoryOthers       SearchFileCategory = "others"
+)
+
+const (
+	searchFileStatusActive  = "active"
+	searchFileStatusDeleted = "deleted"
+)
+
+type searchRequest struct {
+	Query             string        `json:"query"`
+	Options           searchOptions `json:"options"`
+	MatchFieldOptions struct {
+		IncludeHighlights bool `json:"include_highlights"`
+	} `json:"match_field_options"`
+}
+
+type searchOptions struct {
+	Path           string   `json:"path,omitempty"`
+	MaxResults     int      `json:"max_results,omitempty"`
+	FileStatus     tag      `json:"file_status"`
+	FilenameOnly   bool     `json:"filename_only"`
+	FileExtensions []string `json:"file_extensions,omitempty"`
+	FileCategories []tag    `json:"file_categories,omitempty"`
+}
+
+type searchMatch struct {
+	Metadata struct {
+		Tag      string   `json:".tag"`
+		Metadata metadata `json:"metadata"`
+	} `json:"metadata"`
+	MatchType      *tag `json:"match_type,omitempty"`
+	HighlightSpans []struct {
+		HighlightStr  string `json:"highlight_str"`
+		IsHighlighted bool   `json:"is_highlighted"`
+	} `json:"highlight_spans,omitempty"`
+}
+
+type searchResponse struct {
+	Matches []searchMatch `json:"matches"`
+	HasMore bool          `json:"has_more"`
+	Cursor  string        `json:"cursor,omitempty"`
+}
+
+// Search searches files and folders by name and content, returning the first page of matches
+func (f *File) Search(query string, options ...SearchOption) (*searchResponse, error) {
+	return f.search(newSearchRequest(query, options...))
+}
+
+type searchContinueRequest struct {
+	Cursor string `json:"cursor"`
+}
+
+// SearchContinue searches the next page of matches of a previous search, given its cursor
+func (f *File) SearchContinue(cursor string) (*searchResponse, error) {
+	dropboxResponse := &searchResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/search/continue_v2", searchContinueRequest{Cursor: cursor}, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// SearchAll searches all the matches, following the cursor of each page.
+// when the limit is positive, the search stops after that number of matches
+func (f *File) SearchAll(query string, limit int, options ...SearchOption) ([]searchMatch, error) {
+	matches := make([]searchMatch, 0)
+
+	iterator := f.SearchIterator(query, limit, options...)
+	for iterator.Next() {
+		matches = append(matches, *iterator.Entry())
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// SearchIterator returns an iterator over all the matches of the search, following the cursor of each page.
+// when the limit is positive, the iteration stops after that number of matches
+func (f *File) SearchIterator(query string, limit int, options ...SearchOption) *SearchIterator {
+	return &SearchIterator{
+		file:    f,
+		request: newSearchRequest(query, options...),
+		limit:   limit,
+	}
+}
+
+func newSearchRequest(query string, options ...SearchOption) searchRequest {
+	request := searchRequest{
+		Query: query,
+		Options: searchOptions{
+			FileStatus:   tag{Tag: searchFileStatusActive},
+			FilenameOnly: false,
+		},
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
+
+	return request
+}
+
+func (f *File) search(request searchRequest) (*searchResponse, error) {
+	dropboxResponse := &searchResponse{}
+	if err := rpcRequest(f.client, f.config, f.logger, "/files/search_v2", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// SearchIterator iterates over the matches of a search, following the cursor of each page
+type SearchIterator struct {
+	file    *File
+	request searchRequest
+	limit   int
+	count   int
+
+	matches []searchMatch
+	index   int
+	cursor  string
+	hasMore bool
+	started bool
+
+	entry *searchMatch
+	err   error
+}
+
+// Next advances to the next match, fetching the next page when needed. it returns false when there are no more matches or on error
+func (i *SearchIterator) Next() bool {
+	if i.err != nil || (i.limit > 0 && i.count >= i.limit) {
+		return false
+	}
+
+	for i.index >= len(i.matches) {
+		if i.started && !i.hasMore {
+			return false
+		}
+
+		var response *searchResponse
+		if i.started {
+			response, i.err = i.file.SearchContinue(i.cursor)
+		} else {
+			response, i.err = i.file.search(i.request)
+		}
+
+		if i.err != nil {
+			return false
+		}
+
+		i.started = true
+		i.matches = response.Matches
+		i.index = 0
+		i.cursor = response.Cursor
+		i.hasMore = response.HasMore
+	}
+
+	i.entry = &i.matches[i.index]
+	i.index++
+	i.count++
+
+	return true
+}
+
+// Entry returns the current match
+func (i *SearchIterator) Entry() *searchMatch {
+	return i.entry
+}
+
+// Err returns the error that stopped the iteration, if any
+func (i *SearchIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,194 @@
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/joaosoft/manager"
+	"github.com/joaosoft/web"
+)
+
+type uploadSessionStartRequest struct {
+	Close bool `json:"close"`
+}
+
+type uploadSessionStartResponse struct {
+	SessionID string `json:"session_id"`
+}
+
+type uploadSessionCursor struct {
+	SessionID string `json:"session_id"`
+	Offset    int64  `json:"offset"`
+}
+
+type uploadSessionAppendRequest struct {
+	Cursor uploadSessionCursor `json:"cursor"`
+	Close  bool                `json:"close"`
+}
+
+type uploadSessionFinishRequest struct {
+	Cursor uploadSessionCursor `json:"cursor"`
+	Commit uploadFileRequest   `json:"commit"`
+}
+
+// UploadSession uploads the file in chunks through an upload session, allowing files bigger than the 150 MB accepted by Upload
+func (f *File) UploadSession(path string, file []byte, options ...UploadOption) (*uploadFileResponse, error) {
+	return f.uploadSession(context.Background(), path, bytes.NewReader(file), options...)
+}
+
+// UploadSessionStart starts a new upload session with the first chunk of data
+func (f *File) UploadSessionStart(chunk []byte) (*uploadSessionStartResponse, error) {
+	dropboxResponse := &uploadSessionStartResponse{}
+	if err := f.sessionRequest("/files/upload_session/start", uploadSessionStartRequest{Close: false}, chunk, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// UploadSessionAppend appends a chunk of data to the upload session, at the given offset
+func (f *File) UploadSessionAppend(sessionID string, offset int64, chunk []byte) error {
+	args := uploadSessionAppendRequest{
+		Cursor: uploadSessionCursor{
+			SessionID: sessionID,
+			Offset:    offset,
+		},
+		Close: false,
+	}
+
+	return f.sessionRequest("/files/upload_session/append_v2", args, chunk, nil)
+}
+
+// UploadSessionFinish sends the last chunk of data and commits the upload session to the given path
+func (f *File) UploadSessionFinish(sessionID string, offset int64, path string, chunk []byte, options ...UploadOption) (*uploadFileResponse, error) {
+	commit, err := f.newUploadFileRequest(path, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	args := uploadSessionFinishRequest{
+		Cursor: uploadSessionCursor{
+			SessionID: sessionID,
+			Offset:    offset,
+		},
+		Commit: commit,
+	}
+
+	dropboxResponse := &uploadFileResponse{}
+	if err := f.sessionRequest("/files/upload_session/finish", args, chunk, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+func (f *File) uploadSession(ctx context.Context, path string, reader io.Reader, options ...UploadOption) (*uploadFileResponse, error) {
+	chunkSize := f.chunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// the arguments are validated before any chunk is sent, since they are only used when finishing the session
+	if _, err := f.newUploadFileRequest(path, options...); err != nil {
+		return nil, err
+	}
+
+	hash := NewContentHash()
+	reader = io.TeeReader(reader, hash)
+
+	chunk, err := readChunk(reader, chunkSize)
+	if err != nil {
+		err = f.logger.Errorf("error reading chunk to upload to %s", path).ToError()
+		return nil, err
+	}
+
+	session, err := f.UploadSessionStart(chunk)
+	if err != nil {
+		return nil, err
+	}
+	offset := int64(len(chunk))
+
+	// the last chunk, smaller than the chunk size, is sent when finishing the session
+	var last []byte
+	for len(chunk) == chunkSize {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if chunk, err = readChunk(reader, chunkSize); err != nil {
+			err = f.logger.Errorf("error reading chunk to upload to %s", path).ToError()
+			return nil, err
+		}
+
+		if len(chunk) < chunkSize {
+			last = chunk
+			break
+		}
+
+		if err = f.UploadSessionAppend(session.SessionID, offset, chunk); err != nil {
+			return nil, err
+		}
+		offset += int64(len(chunk))
+	}
+
+	dropboxResponse, err := f.UploadSessionFinish(session.SessionID, offset, path, last, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.verifyContentHash(path, hash.String(), dropboxResponse.ContentHash); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+func (f *File) sessionRequest(endpoint string, args interface{}, chunk []byte, dropboxResponse interface{}) error {
+	bodyArgs, err := json.Marshal(args)
+	if err != nil {
+		err = f.logger.Error("errors converting upload session input arguments").ToError()
+		return err
+	}
+
+	headers := manager.Headers{
+		"Authorization":   {fmt.Sprintf("%s %s", f.config.Authorization.Access, f.config.Authorization.Token)},
+		"Dropbox-API-Arg": {string(bodyArgs)},
+	}
+
+	if chunk == nil {
+		chunk = []byte("")
+	}
+
+	if status, response, err := f.client.Request(http.MethodPost, f.config.Hosts.Content, endpoint, string(web.ContentTypeApplicationOctetStream), headers, chunk); err != nil {
+		err = f.logger.WithField("response", response).Errorf("error calling %s", endpoint).ToError()
+		return err
+	} else if status != http.StatusOK {
+		f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return newApiError(status, response)
+	} else if dropboxResponse != nil {
+		if err := json.Unmarshal(response, dropboxResponse); err != nil {
+			err = f.logger.Error("errors converting upload session response data").ToError()
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readChunk(reader io.Reader, size int) ([]byte, error) {
+	chunk := make([]byte, size)
+	n, err := io.ReadFull(reader, chunk)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+
+	return chunk[:n], err
+}
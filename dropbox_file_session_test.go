@@ -0,0 +1,172 @@
+package dropbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// fakeSessionServer is an upload session server that rejects the chunks sent with an offset different from the bytes received
+type fakeSessionServer struct {
+	mu           sync.Mutex
+	sessions     map[string]*bytes.Buffer
+	files        map[string][]byte
+	appends      int
+	finishLength int
+}
+
+func newFakeSessionServer() *fakeSessionServer {
+	return &fakeSessionServer{
+		sessions: make(map[string]*bytes.Buffer),
+		files:    make(map[string][]byte),
+	}
+}
+
+func (s *fakeSessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chunk, _ := ioutil.ReadAll(r.Body)
+	args := []byte(r.Header.Get("Dropbox-API-Arg"))
+
+	switch r.URL.Path {
+	case "/files/upload_session/start":
+		sessionID := fmt.Sprintf("session-%d", len(s.sessions)+1)
+		s.sessions[sessionID] = bytes.NewBuffer(chunk)
+		json.NewEncoder(w).Encode(uploadSessionStartResponse{SessionID: sessionID})
+
+	case "/files/upload_session/append_v2":
+		request := uploadSessionAppendRequest{}
+		json.Unmarshal(args, &request)
+		if !s.checkCursor(w, request.Cursor) {
+			return
+		}
+		s.sessions[request.Cursor.SessionID].Write(chunk)
+		s.appends++
+		w.Write([]byte("null"))
+
+	case "/files/upload_session/finish":
+		request := uploadSessionFinishRequest{}
+		json.Unmarshal(args, &request)
+		if !s.checkCursor(w, request.Cursor) {
+			return
+		}
+		session := s.sessions[request.Cursor.SessionID]
+		session.Write(chunk)
+		s.finishLength = len(chunk)
+		s.files[request.Commit.Path] = session.Bytes()
+		delete(s.sessions, request.Cursor.SessionID)
+
+		hash := NewContentHash()
+		hash.Write(session.Bytes())
+		json.NewEncoder(w).Encode(uploadFileResponse{
+			PathLower:   request.Commit.Path,
+			Size:        session.Len(),
+			ContentHash: hash.String(),
+		})
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeSessionServer) checkCursor(w http.ResponseWriter, cursor uploadSessionCursor) bool {
+	session, ok := s.sessions[cursor.SessionID]
+	if !ok {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_summary": "not_found/..."}`))
+		return false
+	}
+
+	if cursor.Offset != int64(session.Len()) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, `{"error_summary": "incorrect_offset/...", "error": {".tag": "incorrect_offset", "correct_offset": %d}}`, session.Len())
+		return false
+	}
+
+	return true
+}
+
+func TestUploadSession(t *testing.T) {
+	const chunkSize = 4
+
+	tests := []struct {
+		name         string
+		content      string
+		appends      int
+		finishLength int
+	}{
+		{name: "smaller than a chunk", content: "abc", appends: 0, finishLength: 0},
+		{name: "exact multiple of the chunk size", content: "abcdefgh", appends: 1, finishLength: 0},
+		{name: "chunks with a remainder", content: "abcdefghijklmn", appends: 2, finishLength: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFakeSessionServer()
+			service := newTestDropbox(t, server, WithChunkSize(chunkSize))
+
+			response, err := service.File().UploadSession("/file.txt", []byte(test.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if content := string(server.files["/file.txt"]); content != test.content {
+				t.Errorf("uploaded content %q instead of %q", content, test.content)
+			}
+			if response.Size != len(test.content) {
+				t.Errorf("response size %d instead of %d", response.Size, len(test.content))
+			}
+			if server.appends != test.appends {
+				t.Errorf("%d appends instead of %d", server.appends, test.appends)
+			}
+			if server.finishLength != test.finishLength {
+				t.Errorf("finish with %d bytes instead of %d", server.finishLength, test.finishLength)
+			}
+		})
+	}
+}
+
+func TestUploadSessionIncorrectOffset(t *testing.T) {
+	server := newFakeSessionServer()
+	service := newTestDropbox(t, server)
+
+	session, err := service.File().UploadSessionStart([]byte("abcd"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = service.File().UploadSessionAppend(session.SessionID, 2, []byte("efgh"))
+
+	var apiError *ApiError
+	if !errors.As(err, &apiError) || apiError.Status != http.StatusConflict {
+		t.Fatalf("error %v instead of an incorrect offset", err)
+	}
+}
+
+func TestWithChunkSize(t *testing.T) {
+	tests := []struct {
+		size     int
+		expected int
+	}{
+		{size: 0, expected: defaultChunkSize},
+		{size: -1, expected: defaultChunkSize},
+		{size: 4, expected: 4},
+		{size: maxUploadSize, expected: maxUploadSize},
+		{size: 200 << 20, expected: maxUploadSize},
+	}
+
+	for _, test := range tests {
+		service := &Dropbox{chunkSize: defaultChunkSize}
+		service.Reconfigure(WithChunkSize(test.size))
+
+		if service.chunkSize != test.expected {
+			t.Errorf("chunk size %d of %d instead of %d", service.chunkSize, test.size, test.expected)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+)
+
+type uploadIfChangedResponse struct {
+	// Skipped is set when the remote file already has the same content, so nothing was uploaded
+	Skipped bool
+	// Metadata is the metadata of the remote file, when it was skipped
+	Metadata *metadata
+	// Uploaded is the response of the upload, when it was uploaded
+	Uploaded *uploadFileResponse
+}
+
+type uploadIfChangedSummary struct {
+	Uploaded int
+	Skipped  int
+}
+
+// UploadIfChanged uploads the file only when its content hash is different from the one of the remote file
+func (f *File) UploadIfChanged(path string, file []byte, options ...UploadOption) (*uploadIfChangedResponse, error) {
+	hash := NewContentHash()
+	hash.Write(file)
+
+	remote, err := f.remoteFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if remote != nil && remote.ContentHash == hash.String() {
+		return &uploadIfChangedResponse{Skipped: true, Metadata: remote}, nil
+	}
+
+	uploaded, err := f.Upload(path, file, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uploadIfChangedResponse{Uploaded: uploaded}, nil
+}
+
+// UploadDirIfChanged uploads the files of the local folder to the remote folder, streaming only the files with a content hash
+// different from the one of the remote file. the remote folder is listed once, to compare the content hashes of every file.
+// it returns how many files were uploaded and skipped
+func (f *File) UploadDirIfChanged(ctx context.Context, localDir, remoteDir string, options ...UploadOption) (*uploadIfChangedSummary, error) {
+	summary := &uploadIfChangedSummary{}
+
+	remoteHashes, err := f.remoteContentHashes(remoteDir)
+	if err != nil {
+		return summary, err
+	}
+
+	err = filepath.Walk(localDir, func(localFile string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(localDir, localFile)
+		if err != nil {
+			return err
+		}
+		path := pathpkg.Join(remoteDir, filepath.ToSlash(relative))
+
+		uploaded, err := f.uploadFileIfChanged(ctx, localFile, path, info.Size(), remoteHashes[strings.ToLower(path)], options...)
+		if err != nil {
+			return err
+		}
+
+		if uploaded {
+			summary.Uploaded++
+		} else {
+			summary.Skipped++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		f.logger.WithField("error", err).Errorf("error uploading %s to %s", localDir, remoteDir)
+		return summary, err
+	}
+
+	return summary, nil
+}
+
+func (f *File) uploadFileIfChanged(ctx context.Context, localFile, path string, size int64, remoteHash string, options ...UploadOption) (bool, error) {
+	file, err := os.Open(localFile)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	contentHash, err := ComputeContentHash(file)
+	if err != nil {
+		return false, err
+	}
+
+	if remoteHash == contentHash {
+		return false, nil
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	if _, err = f.UploadReader(ctx, path, file, size, options...); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// remoteContentHashes lists the files of the remote folder recursively, returning their content hashes by lower case path.
+// when the remote folder does not exist, there are no files
+func (f *File) remoteContentHashes(remoteDir string) (map[string]string, error) {
+	hashes := make(map[string]string)
+
+	entries, err := f.folder().ListAll(remoteDir, 0, WithListRecursive(true))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return hashes, nil
+		}
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsFile() {
+			hashes[entry.PathLower] = entry.ContentHash
+		}
+	}
+
+	return hashes, nil
+}
+
+// remoteFile gets the metadata of the remote file, or nil when there is no file on the path
+func (f *File) remoteFile(path string) (*metadata, error) {
+	remote, err := f.GetMetadata(path)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !remote.IsFile() {
+		return nil, nil
+	}
+
+	return remote, nil
+}
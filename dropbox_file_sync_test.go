@@ -0,0 +1,70 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUploadDirIfChanged(t *testing.T) {
+	unchanged, _ := ComputeContentHash(strings.NewReader("unchanged"))
+	outdated, _ := ComputeContentHash(strings.NewReader("outdated"))
+
+	uploads := make([]string, 0)
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/list_folder":
+			json.NewEncoder(w).Encode(listFolderResponse{Entries: []metadata{
+				{Tag: metadataTagFolder, PathLower: "/backup/docs"},
+				{Tag: metadataTagFile, PathLower: "/backup/docs/unchanged.txt", ContentHash: unchanged},
+				{Tag: metadataTagFile, PathLower: "/backup/changed.txt", ContentHash: outdated},
+			}})
+
+		case "/files/upload":
+			request := uploadFileRequest{}
+			json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &request)
+			uploads = append(uploads, request.Path)
+
+			content, _ := ioutil.ReadAll(r.Body)
+			contentHash, _ := ComputeContentHash(strings.NewReader(string(content)))
+			json.NewEncoder(w).Encode(uploadFileResponse{PathLower: strings.ToLower(request.Path), ContentHash: contentHash})
+
+		default:
+			// every other call, like getting the metadata of each file, is rejected
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	localDir := t.TempDir()
+	files := map[string]string{
+		"docs/Unchanged.txt": "unchanged",
+		"changed.txt":        "changed",
+		"new.txt":            "new",
+	}
+	for name, content := range files {
+		localFile := filepath.Join(localDir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(localFile), 0755)
+		if err := ioutil.WriteFile(localFile, []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	summary, err := service.File().UploadDirIfChanged(context.Background(), localDir, "/backup")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if summary.Uploaded != 2 || summary.Skipped != 1 {
+		t.Errorf("%d uploaded and %d skipped instead of 2 and 1", summary.Uploaded, summary.Skipped)
+	}
+
+	expected := "/backup/changed.txt,/backup/new.txt"
+	if actual := strings.Join(uploads, ","); actual != expected {
+		t.Errorf("uploads %s instead of %s", actual, expected)
+	}
+}
@@ -0,0 +1,216 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeDownloadServer serves the revisions of a file, with range requests and without ranges past the end of the file
+type fakeDownloadServer struct {
+	revisions map[string]string
+	current   string
+	ranges    []string
+}
+
+func (s *fakeDownloadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/files/get_metadata":
+		json.NewEncoder(w).Encode(s.metadata(s.current))
+
+	case "/files/download":
+		request := downloadFileRequest{}
+		json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &request)
+
+		rev := s.current
+		if strings.HasPrefix(request.Path, "rev:") {
+			rev = strings.TrimPrefix(request.Path, "rev:")
+		}
+		content := s.revisions[rev]
+
+		result, _ := json.Marshal(s.metadata(rev))
+		w.Header().Set("Dropbox-API-Result", string(result))
+
+		byteRange := r.Header.Get("Range")
+		if byteRange == "" {
+			w.Write([]byte(content))
+			return
+		}
+
+		s.ranges = append(s.ranges, byteRange)
+		offset, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(byteRange, "bytes="), "-"))
+		if offset >= len(content) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(content[offset:]))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *fakeDownloadServer) metadata(rev string) downloadFileResponse {
+	contentHash, _ := ComputeContentHash(strings.NewReader(s.revisions[rev]))
+
+	return downloadFileResponse{
+		PathLower:   "/file.txt",
+		Rev:         rev,
+		Size:        len(s.revisions[rev]),
+		ContentHash: contentHash,
+	}
+}
+
+func TestDownloadResume(t *testing.T) {
+	revisions := map[string]string{
+		"rev1":  "hello world",
+		"rev2":  "goodbye world",
+		"empty": "",
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		local   string
+		ranges  []string
+	}{
+		{name: "new file", current: "rev1", local: "", ranges: nil},
+		{name: "partial file", current: "rev1", local: "hello", ranges: []string{"bytes=5-"}},
+		{name: "complete file", current: "rev1", local: "hello world", ranges: []string{"bytes=10-"}},
+		{name: "partial file of another revision", current: "rev2", local: "hello", ranges: []string{"bytes=5-"}},
+		{name: "longer file of another revision", current: "rev1", local: "goodbye world", ranges: nil},
+		{name: "empty remote file", current: "empty", local: "hello", ranges: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakeDownloadServer{revisions: revisions, current: test.current}
+			service := newTestDropbox(t, server)
+
+			localFile := filepath.Join(t.TempDir(), "file.txt")
+			if err := ioutil.WriteFile(localFile, []byte(test.local), 0644); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			response, err := service.File().DownloadResume(context.Background(), "/file.txt", localFile)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			content, _ := ioutil.ReadFile(localFile)
+			if string(content) != revisions[test.current] {
+				t.Errorf("local content %q instead of %q", content, revisions[test.current])
+			}
+			if response.Rev != test.current {
+				t.Errorf("revision %s instead of %s", response.Rev, test.current)
+			}
+			if fmt.Sprint(server.ranges) != fmt.Sprint(test.ranges) {
+				t.Errorf("ranges %v instead of %v", server.ranges, test.ranges)
+			}
+		})
+	}
+}
+
+func TestUploadModeUpdateWithoutRevision(t *testing.T) {
+	requests := 0
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+
+	if _, err := service.File().Upload("/file.txt", []byte("hello"), WithUploadModeUpdate("")); err == nil {
+		t.Errorf("upload without error")
+	}
+
+	if _, err := service.File().UploadReader(context.Background(), "/file.txt", strings.NewReader("hello"), 5, WithUploadModeUpdate("")); err == nil {
+		t.Errorf("upload of the reader without error")
+	}
+
+	if _, err := service.File().UploadSession("/file.txt", []byte("hello"), WithUploadModeUpdate("")); err == nil {
+		t.Errorf("upload session without error")
+	}
+
+	if requests != 0 {
+		t.Errorf("%d requests instead of none", requests)
+	}
+}
+
+func TestUploadModeUpdateConflict(t *testing.T) {
+	const conflict = `{"error_summary": "path/conflict/file/..", "error": {".tag": "path", "reason": {".tag": "conflict", "conflict": {".tag": "file"}}}}`
+
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/upload_session/start":
+			w.Write([]byte(`{"session_id": "session-1"}`))
+		case "/files/upload_session/finish":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_summary": "path/conflict/file/..", "error": {".tag": "path", "path": {".tag": "conflict", "conflict": {".tag": "file"}}}}`))
+		case "/files/upload":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(conflict))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	_, err := service.File().Upload("/file.txt", []byte("hello"), WithUploadModeUpdate("rev1"))
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("upload error %v does not match the conflict", err)
+	}
+
+	_, err = service.File().UploadReader(context.Background(), "/file.txt", strings.NewReader("hello"), 5, WithUploadModeUpdate("rev1"))
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("upload of the reader error %v does not match the conflict", err)
+	}
+
+	_, err = service.File().UploadSession("/file.txt", []byte("hello"), WithUploadModeUpdate("rev1"))
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("upload session error %v does not match the conflict", err)
+	}
+
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("upload session error %v matches not found", err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := getMetadataRequest{}
+		json.NewDecoder(r.Body).Decode(&request)
+
+		switch request.Path {
+		case "/file.txt":
+			json.NewEncoder(w).Encode(metadata{Tag: metadataTagFile, PathLower: "/file.txt"})
+		case "/missing.txt":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_summary": "path/not_found/..", "error": {".tag": "path", "path": {".tag": "not_found"}}}`))
+		default:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_summary": "path/malformed_path/..", "error": {".tag": "path", "path": {".tag": "malformed_path"}}}`))
+		}
+	}))
+
+	if _, err := service.File().GetMetadata("/missing.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("error %v does not match not found", err)
+	}
+
+	if exists, err := service.File().Exists("/file.txt"); !exists || err != nil {
+		t.Errorf("exists %t with error %v instead of true", exists, err)
+	}
+
+	if exists, err := service.File().Exists("/missing.txt"); exists || err != nil {
+		t.Errorf("exists %t with error %v instead of false without error", exists, err)
+	}
+
+	if _, err := service.File().Exists("malformed"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("error %v instead of an error other than not found", err)
+	}
+}
@@ -0,0 +1,236 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+)
+
+// ThumbnailFormat ...
+type ThumbnailFormat string
+
+const (
+	ThumbnailFormatJpeg ThumbnailFormat = "jpeg"
+	ThumbnailFormatPng  ThumbnailFormat = "png"
+)
+
+// ThumbnailSize ...
+type ThumbnailSize string
+
+const (
+	ThumbnailSizeW32H32     ThumbnailSize = "w32h32"
+	ThumbnailSizeW64H64     ThumbnailSize = "w64h64"
+	ThumbnailSizeW128H128   ThumbnailSize = "w128h128"
+	ThumbnailSizeW256H256   ThumbnailSize = "w256h256"
+	ThumbnailSizeW480H320   ThumbnailSize = "w480h320"
+	ThumbnailSizeW640H480   ThumbnailSize = "w640h480"
+	ThumbnailSizeW960H640   ThumbnailSize = "w960h640"
+	ThumbnailSizeW1024H768  ThumbnailSize = "w1024h768"
+	ThumbnailSizeW2048H1536 ThumbnailSize = "w2048h1536"
+)
+
+// ThumbnailMode ...
+type ThumbnailMode string
+
+const (
+	// ThumbnailModeStrict scales down the image to fit within the given size
+	ThumbnailModeStrict ThumbnailMode = "strict"
+	// ThumbnailModeBestFit scales down the image to fit within the given size or its transpose
+	ThumbnailModeBestFit ThumbnailMode = "bestfit"
+	// ThumbnailModeFitOneBestFit scales down the image to completely cover the given size or its transpose
+	ThumbnailModeFitOneBestFit ThumbnailMode = "fitone_bestfit"
+)
+
+type getThumbnailRequest struct {
+	Resource struct {
+		Tag  string `json:".tag"`
+		Path string `json:"path"`
+	} `json:"resource"`
+	Format tag `json:"format"`
+	Size   tag `json:"size"`
+	Mode   tag `json:"mode"`
+}
+
+type getThumbnailResponse struct {
+	FileMetadata metadata `json:"file_metadata"`
+}
+
+// thumbnailTag returns the tag of the value, or of the default value of the api when it is empty
+func thumbnailTag(value, defaultValue string) tag {
+	if value == "" {
+		return tag{Tag: defaultValue}
+	}
+	return tag{Tag: value}
+}
+
+// GetThumbnail gets a thumbnail of an image as a stream, together with the metadata of the image.
+// the empty format, size and mode default to jpeg, w64h64 and strict. the caller is responsible for closing the returned stream
+func (f *File) GetThumbnail(ctx context.Context, path string, format ThumbnailFormat, size ThumbnailSize, mode ThumbnailMode) (io.ReadCloser, *metadata, error) {
+	args := getThumbnailRequest{
+		Format: thumbnailTag(string(format), string(ThumbnailFormatJpeg)),
+		Size:   thumbnailTag(string(size), string(ThumbnailSizeW64H64)),
+		Mode:   thumbnailTag(string(mode), string(ThumbnailModeStrict)),
+	}
+	args.Resource.Tag = "path"
+	args.Resource.Path = path
+
+	response, err := contentRequest(ctx, f.httpClient, f.config, "/files/get_thumbnail_v2", args, nil, 0, nil)
+	if err != nil {
+		f.logger.WithField("error", err).Errorf("error getting thumbnail of %s", path)
+		return nil, nil, err
+	}
+
+	dropboxResponse := &getThumbnailResponse{}
+	if err := json.Unmarshal([]byte(response.Header.Get("Dropbox-API-Result")), dropboxResponse); err != nil {
+		response.Body.Close()
+		err = f.logger.Error("errors converting thumbnail response metadata").ToError()
+		return nil, nil, err
+	}
+
+	return response.Body, &dropboxResponse.FileMetadata, nil
+}
+
+// ThumbnailBatchEntry is an image of a batch of thumbnails.
+// the empty format, size and mode default to jpeg, w64h64 and strict
+type ThumbnailBatchEntry struct {
+	Path   string          `json:"path"`
+	Format ThumbnailFormat `json:"-"`
+	Size   ThumbnailSize   `json:"-"`
+	Mode   ThumbnailMode   `json:"-"`
+}
+
+type getThumbnailBatchEntry struct {
+	Path   string `json:"path"`
+	Format tag    `json:"format"`
+	Size   tag    `json:"size"`
+	Mode   tag    `json:"mode"`
+}
+
+type getThumbnailBatchRequest struct {
+	Entries []getThumbnailBatchEntry `json:"entries"`
+}
+
+type getThumbnailBatchResponse struct {
+	Entries []struct {
+		Tag       string          `json:".tag"`
+		Metadata  *metadata       `json:"metadata,omitempty"`
+		Thumbnail string          `json:"thumbnail,omitempty"`
+		Failure   json.RawMessage `json:"failure,omitempty"`
+	} `json:"entries"`
+}
+
+type thumbnailBatchResult struct {
+	Path      string
+	Metadata  *metadata
+	Thumbnail []byte
+	// Failure is the summary of the error when the entry failed, like path/not_found
+	Failure string
+}
+
+// Success ...
+func (r *thumbnailBatchResult) Success() bool {
+	return r.Failure == ""
+}
+
+// GetThumbnailBatch gets the thumbnails of multiple images at once, with the thumbnails already decoded.
+// the entries are sent in requests of up to 25 entries, stopping between them when the context is cancelled.
+// the results are in the same order of the entries
+func (f *File) GetThumbnailBatch(ctx context.Context, entries []ThumbnailBatchEntry) ([]thumbnailBatchResult, error) {
+	results := make([]thumbnailBatchResult, 0, len(entries))
+
+	for start := 0; start < len(entries); start += maxThumbnailBatchEntries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := start + maxThumbnailBatchEntries
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		chunkResults, err := f.thumbnailBatch(entries[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+func (f *File) thumbnailBatch(entries []ThumbnailBatchEntry) ([]thumbnailBatchResult, error) {
+	request := getThumbnailBatchRequest{
+		Entries: make([]getThumbnailBatchEntry, len(entries)),
+	}
+
+	for i, entry := range entries {
+		request.Entries[i] = getThumbnailBatchEntry{
+			Path:   entry.Path,
+			Format: thumbnailTag(string(entry.Format), string(ThumbnailFormatJpeg)),
+			Size:   thumbnailTag(string(entry.Size), string(ThumbnailSizeW64H64)),
+			Mode:   thumbnailTag(string(entry.Mode), string(ThumbnailModeStrict)),
+		}
+	}
+
+	dropboxResponse := &getThumbnailBatchResponse{}
+	if err := rpcHostRequest(f.client, f.config, f.logger, f.config.Hosts.Content, "/files/get_thumbnail_batch", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	results := make([]thumbnailBatchResult, len(dropboxResponse.Entries))
+	for i, entry := range dropboxResponse.Entries {
+		if i < len(entries) {
+			results[i].Path = entries[i].Path
+		}
+
+		switch entry.Tag {
+		case batchTagSuccess:
+			thumbnail, err := base64.StdEncoding.DecodeString(entry.Thumbnail)
+			if err != nil {
+				err = f.logger.Errorf("errors decoding thumbnail of %s", results[i].Path).ToError()
+				return nil, err
+			}
+			results[i].Metadata = entry.Metadata
+			results[i].Thumbnail = thumbnail
+		case batchTagFailure:
+			if results[i].Failure = unionSummary(entry.Failure); results[i].Failure == "" {
+				results[i].Failure = batchTagFailure
+			}
+		default:
+			results[i].Failure = entry.Tag
+		}
+	}
+
+	return results, nil
+}
+
+type getPreviewRequest struct {
+	Path string `json:"path"`
+}
+
+type getPreviewResponse struct {
+	metadata
+	// ContentType is application/pdf or text/html, depending on the type of the file
+	ContentType string `json:"-"`
+}
+
+// GetPreview gets a preview of a document as a stream, in pdf or html, together with the metadata of the document.
+// the caller is responsible for closing the returned stream
+func (f *File) GetPreview(ctx context.Context, path string) (io.ReadCloser, *getPreviewResponse, error) {
+	response, err := contentRequest(ctx, f.httpClient, f.config, "/files/get_preview", getPreviewRequest{Path: path}, nil, 0, nil)
+	if err != nil {
+		f.logger.WithField("error", err).Errorf("error getting preview of %s", path)
+		return nil, nil, err
+	}
+
+	dropboxResponse := &getPreviewResponse{}
+	if err := json.Unmarshal([]byte(response.Header.Get("Dropbox-API-Result")), dropboxResponse); err != nil {
+		response.Body.Close()
+		err = f.logger.Error("errors converting preview response metadata").ToError()
+		return nil, nil, err
+	}
+	dropboxResponse.ContentType = response.Header.Get("Content-Type")
+
+	return response.Body, dropboxResponse, nil
+}
@@ -0,0 +1,75 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeThumbnailServer is a thumbnail batch server that rejects empty tags and more than maxThumbnailBatchEntries entries
+type fakeThumbnailServer struct {
+	requests int
+}
+
+func (s *fakeThumbnailServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := getThumbnailBatchRequest{}
+	json.NewDecoder(r.Body).Decode(&request)
+
+	if len(request.Entries) > maxThumbnailBatchEntries {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.requests++
+
+	entries := make([]string, 0)
+	for _, entry := range request.Entries {
+		if entry.Format.Tag == "" || entry.Size.Tag == "" || entry.Mode.Tag == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		thumbnail := base64.StdEncoding.EncodeToString([]byte(entry.Path + ":" + entry.Format.Tag + ":" + entry.Size.Tag + ":" + entry.Mode.Tag))
+		entries = append(entries, fmt.Sprintf(`{".tag": "success", "metadata": {".tag": "file", "path_lower": %q}, "thumbnail": %q}`, entry.Path, thumbnail))
+	}
+	fmt.Fprintf(w, `{"entries": [%s]}`, strings.Join(entries, ","))
+}
+
+func TestGetThumbnailBatch(t *testing.T) {
+	entries := make([]ThumbnailBatchEntry, 60)
+	for i := range entries {
+		entries[i] = ThumbnailBatchEntry{Path: fmt.Sprintf("/image-%d.png", i)}
+	}
+	entries[0].Format = ThumbnailFormatPng
+
+	server := &fakeThumbnailServer{}
+	service := newTestDropbox(t, server)
+
+	results, err := service.File().GetThumbnailBatch(context.Background(), entries)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if server.requests != 3 {
+		t.Errorf("%d batch requests instead of 3", server.requests)
+	}
+
+	if len(results) != len(entries) {
+		t.Fatalf("%d results instead of %d", len(results), len(entries))
+	}
+
+	for i, result := range results {
+		format := ThumbnailFormatJpeg
+		if i == 0 {
+			format = ThumbnailFormatPng
+		}
+
+		expected := fmt.Sprintf("%s:%s:w64h64:strict", entries[i].Path, format)
+		if result.Path != entries[i].Path || string(result.Thumbnail) != expected {
+			t.Errorf("result %d of %s with thumbnail %q instead of %q", i, result.Path, result.Thumbnail, expected)
+		}
+	}
+}
@@ -1,89 +1,140 @@
 package dropbox
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
+	"strings"
 
-	"github.com/joaosoft/web"
 	"github.com/joaosoft/logger"
 	"github.com/joaosoft/manager"
+	"github.com/joaosoft/web"
 )
 
 type Folder struct {
-	client manager.IGateway
-	config *DropboxConfig
-	logger logger.ILogger
+	client     manager.IGateway
+	httpClient *http.Client
+	config     *DropboxConfig
+	logger     logger.ILogger
 }
 
 type listFolderRequest struct {
-	Path                            string `json:"path"`
-	Recursive                       bool   `json:"recursive"`
-	IncludeMediaInfo                bool   `json:"include_media_info"`
-	IncludeDeleted                  bool   `json:"include_deleted"`
-	IncludeHasExplicitSharedMembers bool   `json:"include_has_explicit_shared_members"`
-	IncludeMountedFolders           bool   `json:"include_mounted_folders"`
+	Path                            string         `json:"path"`
+	Recursive                       bool           `json:"recursive"`
+	IncludeMediaInfo                bool           `json:"include_media_info"`
+	IncludeDeleted                  bool           `json:"include_deleted"`
+	IncludeHasExplicitSharedMembers bool           `json:"include_has_explicit_shared_members"`
+	IncludeMountedFolders           bool           `json:"include_mounted_folders"`
+	IncludeNonDownloadableFiles     bool           `json:"include_non_downloadable_files"`
+	Limit                           int            `json:"limit,omitempty"`
+	SharedLink                      *sharedLinkArg `json:"shared_link,omitempty"`
 }
 
-type listFolderResponse struct {
-	Entries []struct {
-		Tag            string    `json:".tag"`
-		Name           string    `json:"name"`
-		ID             string    `json:"id"`
-		ClientModified time.Time `json:"client_modified,omitempty"`
-		ServerModified time.Time `json:"server_modified,omitempty"`
-		Rev            string    `json:"rev,omitempty"`
-		Size           int       `json:"size,omitempty"`
-		PathLower      string    `json:"path_lower"`
-		PathDisplay    string    `json:"path_display"`
-		SharingInfo    struct {
-			ReadOnly             bool   `json:"read_only"`
-			ParentSharedFolderID string `json:"parent_shared_folder_id"`
-			ModifiedBy           string `json:"modified_by"`
-		} `json:"sharing_info"`
-		PropertyGroups []struct {
-			TemplateID string `json:"template_id"`
-			Fields     []struct {
-				Name  string `json:"name"`
-				Value string `json:"value"`
-			} `json:"fields"`
-		} `json:"property_groups"`
-		HasExplicitSharedMembers bool   `json:"has_explicit_shared_members,omitempty"`
-		ContentHash              string `json:"content_hash,omitempty"`
-	} `json:"entries"`
-	Cursor  string `json:"cursor"`
-	HasMore bool   `json:"has_more"`
+type sharedLinkArg struct {
+	URL      string `json:"url"`
+	Password string `json:"password,omitempty"`
 }
 
-func (f *Folder) List(path string) (*listFolderResponse, error) {
+func newListFolderRequest(path string, options ...ListFolderOption) listFolderRequest {
 	if path == "/" {
 		path = ""
 	}
-	body, err := json.Marshal(listFolderRequest{
+
+	request := listFolderRequest{
 		Path:                            path,
 		Recursive:                       false,
 		IncludeMediaInfo:                false,
 		IncludeDeleted:                  false,
 		IncludeHasExplicitSharedMembers: false,
 		IncludeMountedFolders:           true,
+		IncludeNonDownloadableFiles:     true,
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
+
+	return request
+}
+
+type listFolderResponse struct {
+	Entries []metadata `json:"entries"`
+	Cursor  string     `json:"cursor"`
+	HasMore bool       `json:"has_more"`
+}
+
+func (f *Folder) List(path string, options ...ListFolderOption) (*listFolderResponse, error) {
+	return f.list(newListFolderRequest(path, options...))
+}
+
+type listFolderContinueRequest struct {
+	Cursor string `json:"cursor"`
+}
+
+// ListContinue lists the next page of entries of a previous listing, given its cursor
+func (f *Folder) ListContinue(cursor string) (*listFolderResponse, error) {
+	body, err := json.Marshal(listFolderContinueRequest{
+		Cursor: cursor,
 	})
 	if err != nil {
 		err = f.logger.Error("error marshal bodyArgs").ToError()
 		return nil, err
 	}
 
+	return f.listRequest("/files/list_folder/continue", body)
+}
+
+// ListAll lists all the entries of the folder, following the cursor of each page.
+// when the limit is positive, the listing stops after that number of entries
+func (f *Folder) ListAll(path string, limit int, options ...ListFolderOption) ([]metadata, error) {
+	entries := make([]metadata, 0)
+
+	iterator := f.Iterator(path, limit, options...)
+	for iterator.Next() {
+		entries = append(entries, *iterator.Entry())
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Iterator returns an iterator over all the entries of the folder, following the cursor of each page.
+// when the limit is positive, the iteration stops after that number of entries
+func (f *Folder) Iterator(path string, limit int, options ...ListFolderOption) *FolderIterator {
+	return &FolderIterator{
+		folder:  f,
+		request: newListFolderRequest(path, options...),
+		limit:   limit,
+	}
+}
+
+func (f *Folder) list(request listFolderRequest) (*listFolderResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		err = f.logger.Error("error marshal bodyArgs").ToError()
+		return nil, err
+	}
+
+	return f.listRequest("/files/list_folder", body)
+}
+
+func (f *Folder) listRequest(endpoint string, body []byte) (*listFolderResponse, error) {
 	headers := manager.Headers{
 		"Authorization": {fmt.Sprintf("%s %s", f.config.Authorization.Access, f.config.Authorization.Token)},
 	}
 
 	dropboxResponse := &listFolderResponse{}
-	if status, response, err := f.client.Request(http.MethodPost, f.config.Hosts.Api, "/files/list_folder", string(web.ContentTypeApplicationJSON), headers, body); err != nil {
+	if status, response, err := f.client.Request(http.MethodPost, f.config.Hosts.Api, endpoint, string(web.ContentTypeApplicationJSON), headers, body); err != nil {
 		err = f.logger.WithField("response", response).Error("error listing Folder").ToError()
 		return nil, err
 	} else if status != http.StatusOK {
-		err = f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK).ToError()
-		return nil, err
+		f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return nil, newApiError(status, response)
 	} else if response == nil {
 		err = f.logger.Error("error listing Folder").ToError()
 		return nil, err
@@ -94,8 +145,6 @@ func (f *Folder) List(path string) (*listFolderResponse, error) {
 		}
 		return dropboxResponse, nil
 	}
-
-	return nil, nil
 }
 
 type createFolderRequest struct {
@@ -134,8 +183,7 @@ func (f *Folder) Create(path string) (*createFolderResponse, error) {
 		AutoRename: false,
 	})
 	if err != nil {
-		err := err
-		f.logger.Error("error marshal bodyArgs").ToError()
+		err = f.logger.Error("error marshal bodyArgs").ToError()
 		return nil, err
 	}
 
@@ -148,8 +196,8 @@ func (f *Folder) Create(path string) (*createFolderResponse, error) {
 		err = f.logger.WithField("response", response).Error("error creating Folder").ToError()
 		return nil, err
 	} else if status != http.StatusOK {
-		err = f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK).ToError()
-		return nil, err
+		f.logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return nil, newApiError(status, response)
 	} else if response == nil {
 		err = f.logger.Error("error creating Folder").ToError()
 		return nil, err
@@ -160,15 +208,98 @@ func (f *Folder) Create(path string) (*createFolderResponse, error) {
 		}
 		return dropboxResponse, nil
 	}
+}
+
+// MkdirAll creates the folder and all its missing parents, returning the paths of the created folders.
+// the folders that already exist are not an error, so it can be called repeatedly
+func (f *Folder) MkdirAll(path string) ([]string, error) {
+	created := make([]string, 0)
 
-	return nil, nil
+	current := ""
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		current += "/" + name
+
+		response, err := f.Create(current)
+		if err != nil {
+			var apiError *ApiError
+			if errors.As(err, &apiError) && strings.HasPrefix(apiError.Summary, failureFolderConflict) {
+				continue
+			}
+			return created, err
+		}
+
+		created = append(created, response.Metadata.PathDisplay)
+	}
+
+	return created, nil
 }
 
-func (f *Folder) DeleteFolder(path string) (*deleteFileResponse, error) {
-	file := File{
-		client: f.client,
-		config: f.config,
+type createFolderBatchRequest struct {
+	Paths      []string `json:"paths"`
+	AutoRename bool     `json:"autorename"`
+	ForceAsync bool     `json:"force_async"`
+}
+
+type createFolderBatchResult struct {
+	batchEntryResult
+	// AlreadyExists is set, instead of the failure, when there is already a folder on the path
+	AlreadyExists bool
+}
+
+// CreateBatch creates multiple folders at once, waiting for the async job to finish when it runs asynchronously.
+// the paths are sent in requests of up to 1000 entries, and the results are in the same order of the paths
+func (f *Folder) CreateBatch(ctx context.Context, paths []string, options ...CreateFolderBatchOption) ([]createFolderBatchResult, error) {
+	entries, err := f.file().batch(ctx, "/files/create_folder_batch", "/files/create_folder_batch/check", paths, func(start, end int) interface{} {
+		request := createFolderBatchRequest{
+			Paths:      paths[start:end],
+			AutoRename: false,
+			ForceAsync: false,
+		}
+
+		for _, option := range options {
+			option(&request)
+		}
+
+		return request
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]createFolderBatchResult, len(entries))
+	for i, entry := range entries {
+		results[i].batchEntryResult = entry
+		if entry.Failure == failureFolderConflict {
+			results[i].Failure = ""
+			results[i].AlreadyExists = true
+		}
 	}
 
-	return file.Delete(path)
+	return results, nil
+}
+
+func (f *Folder) DeleteFolder(path string) (*deleteFileResponse, error) {
+	return f.file().Delete(path)
+}
+
+// Move moves a folder to a new path, returning the metadata at the new path
+func (f *Folder) Move(from, to string, options ...RelocationOption) (*relocationResponse, error) {
+	return f.file().Move(from, to, options...)
+}
+
+// Copy copies a folder to a new path, returning the metadata of the copy
+func (f *Folder) Copy(from, to string, options ...RelocationOption) (*relocationResponse, error) {
+	return f.file().Copy(from, to, options...)
+}
+
+func (f *Folder) file() *File {
+	return &File{
+		client:     f.client,
+		httpClient: f.httpClient,
+		config:     f.config,
+		logger:     f.logger,
+	}
 }
@@ -0,0 +1,69 @@
+package dropbox
+
+// FolderIterator iterates over the entries of a folder, following the cursor of each page
+type FolderIterator struct {
+	folder  *Folder
+	request listFolderRequest
+	limit   int
+	count   int
+
+	entries []metadata
+	index   int
+	cursor  string
+	hasMore bool
+	started bool
+
+	entry *metadata
+	err   error
+}
+
+// Next advances to the next entry, fetching the next page when needed. it returns false when there are no more entries or on error
+func (i *FolderIterator) Next() bool {
+	if i.err != nil || (i.limit > 0 && i.count >= i.limit) {
+		return false
+	}
+
+	for i.index >= len(i.entries) {
+		if i.started && !i.hasMore {
+			return false
+		}
+
+		var response *listFolderResponse
+		if i.started {
+			response, i.err = i.folder.ListContinue(i.cursor)
+		} else {
+			response, i.err = i.folder.list(i.request)
+		}
+
+		if i.err != nil {
+			return false
+		}
+
+		i.started = true
+		i.entries = response.Entries
+		i.index = 0
+		i.cursor = response.Cursor
+		i.hasMore = response.HasMore
+	}
+
+	i.entry = &i.entries[i.index]
+	i.index++
+	i.count++
+
+	return true
+}
+
+// Entry returns the current entry
+func (i *FolderIterator) Entry() *metadata {
+	return i.entry
+}
+
+// Cursor returns the cursor of the last fetched page
+func (i *FolderIterator) Cursor() string {
+	return i.cursor
+}
+
+// Err returns the error that stopped the iteration, if any
+func (i *FolderIterator) Err() error {
+	return i.err
+}
@@ -0,0 +1,95 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakePagesServer lists a folder in pages, with an empty page in the middle
+type fakePagesServer struct {
+	requests int
+}
+
+func (s *fakePagesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := listFolderContinueRequest{}
+	json.NewDecoder(r.Body).Decode(&request)
+	s.requests++
+
+	pages := map[string]listFolderResponse{
+		"":         {Entries: []metadata{{PathLower: "/folder/a"}, {PathLower: "/folder/b"}}, Cursor: "cursor-1", HasMore: true},
+		"cursor-1": {Entries: []metadata{}, Cursor: "cursor-2", HasMore: true},
+		"cursor-2": {Entries: []metadata{{PathLower: "/folder/c"}, {PathLower: "/folder/d"}}, Cursor: "cursor-3", HasMore: true},
+		"cursor-3": {Entries: []metadata{{PathLower: "/folder/e"}}, Cursor: "cursor-4", HasMore: false},
+	}
+
+	switch r.URL.Path {
+	case "/files/list_folder":
+		json.NewEncoder(w).Encode(pages[""])
+	case "/files/list_folder/continue":
+		page, ok := pages[request.Cursor]
+		if !ok || request.Cursor == "" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_summary": "reset/..."}`))
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		expected string
+		requests int
+	}{
+		{name: "without limit", limit: 0, expected: "/folder/a,/folder/b,/folder/c,/folder/d,/folder/e", requests: 4},
+		{name: "limit on the first page", limit: 2, expected: "/folder/a,/folder/b", requests: 1},
+		{name: "limit after the empty page", limit: 3, expected: "/folder/a,/folder/b,/folder/c", requests: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := &fakePagesServer{}
+			service := newTestDropbox(t, server)
+
+			entries, err := service.Folder().ListAll("/folder", test.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			paths := make([]string, len(entries))
+			for i, entry := range entries {
+				paths[i] = entry.PathLower
+			}
+
+			if actual := strings.Join(paths, ","); actual != test.expected {
+				t.Errorf("entries %s instead of %s", actual, test.expected)
+			}
+			if server.requests != test.requests {
+				t.Errorf("%d requests instead of %d", server.requests, test.requests)
+			}
+		})
+	}
+}
+
+func TestFolderIteratorError(t *testing.T) {
+	service := newTestDropbox(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_summary": "path/not_found/..."}`))
+	}))
+
+	iterator := service.Folder().Iterator("/missing", 0)
+	if iterator.Next() {
+		t.Fatalf("iteration of a missing folder")
+	}
+
+	if err := iterator.Err(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("error %v does not match not found", err)
+	}
+}
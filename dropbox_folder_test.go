@@ -0,0 +1,62 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeFoldersServer creates folders, with conflicts on the paths that already exist as a folder or a file
+type fakeFoldersServer struct {
+	paths map[string]string
+}
+
+func (s *fakeFoldersServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := createFolderRequest{}
+	json.NewDecoder(r.Body).Decode(&request)
+
+	if kind, ok := s.paths[strings.ToLower(request.Path)]; ok {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, `{"error_summary": "path/conflict/%s/..", "error": {".tag": "path", "path": {".tag": "conflict", "conflict": {".tag": %q}}}}`, kind, kind)
+		return
+	}
+
+	s.paths[strings.ToLower(request.Path)] = metadataTagFolder
+	fmt.Fprintf(w, `{"metadata": {"path_lower": %q, "path_display": %q}}`, strings.ToLower(request.Path), request.Path)
+}
+
+func TestMkdirAll(t *testing.T) {
+	server := &fakeFoldersServer{paths: map[string]string{
+		"/a":          metadataTagFolder,
+		"/a/b":        metadataTagFolder,
+		"/a/file.txt": metadataTagFile,
+	}}
+	service := newTestDropbox(t, server)
+
+	created, err := service.Folder().MkdirAll("/a/b/c/d")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if actual := strings.Join(created, ","); actual != "/a/b/c,/a/b/c/d" {
+		t.Errorf("created %s instead of /a/b/c,/a/b/c/d", actual)
+	}
+
+	// running it again creates nothing
+	created, err = service.Folder().MkdirAll("/a/b/c/d")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if created == nil || len(created) != 0 {
+		t.Errorf("created %v instead of none", created)
+	}
+
+	// a file on the path is an error
+	if _, err = service.Folder().MkdirAll("/a/file.txt/e"); err == nil {
+		t.Errorf("no error with a file on the path")
+	}
+	if _, ok := server.paths["/a/file.txt/e"]; ok {
+		t.Errorf("folder created under a file")
+	}
+}
@@ -0,0 +1,251 @@
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/joaosoft/web"
+)
+
+// WatchEventType ...
+type WatchEventType string
+
+const (
+	// listFolderReset is the error summary of a cursor that is no longer valid and requires listing the folder again
+	listFolderReset = "reset"
+
+	WatchEventAdded    WatchEventType = "added"
+	WatchEventModified WatchEventType = "modified"
+	WatchEventDeleted  WatchEventType = "deleted"
+)
+
+// WatchEvent is a change on an entry of a watched folder.
+// when the watch stops on an error that can not be retried, the last event has only that error
+type WatchEvent struct {
+	Type  WatchEventType
+	Entry metadata
+	Err   error
+}
+
+type listFolderLongpollRequest struct {
+	Cursor  string `json:"cursor"`
+	Timeout int    `json:"timeout"`
+}
+
+type listFolderLongpollResponse struct {
+	Changes bool `json:"changes"`
+	Backoff int  `json:"backoff"`
+}
+
+// Watch emits the changes on the entries of the folder until the context is cancelled, when the channel is closed.
+// the changes are waited with long polling and errors while watching are logged and retried. when the cursor is reset,
+// the folder is listed again and the differences are emitted. other api errors that can not be retried stop the watch,
+// with an event with the error before the channel is closed
+func (f *Folder) Watch(ctx context.Context, path string, options ...ListFolderOption) (<-chan WatchEvent, error) {
+	// the current entries are listed to know if a changed entry is new or modified
+	cursor, known, err := f.watchList(path, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan WatchEvent)
+	go f.watch(ctx, path, options, cursor, known, events)
+
+	return events, nil
+}
+
+// watchList lists the entries of the folder, returning the cursor and the entries that are not deleted, by path
+func (f *Folder) watchList(path string, options ...ListFolderOption) (string, map[string]metadata, error) {
+	known := make(map[string]metadata)
+
+	iterator := f.Iterator(path, 0, options...)
+	for iterator.Next() {
+		if entry := iterator.Entry(); !entry.IsDeleted() {
+			known[entry.PathLower] = *entry
+		}
+	}
+
+	if err := iterator.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return iterator.Cursor(), known, nil
+}
+
+func (f *Folder) watch(ctx context.Context, path string, options []ListFolderOption, cursor string, known map[string]metadata, events chan<- WatchEvent) {
+	defer close(events)
+
+	for {
+		var backoff time.Duration
+		var err error
+
+		if cursor, backoff, err = f.watchChanges(ctx, cursor, known, events); err == nil {
+			if backoff > 0 && !wait(ctx, backoff) {
+				return
+			}
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		var apiError *ApiError
+		if errors.As(err, &apiError) && strings.HasPrefix(apiError.Summary, listFolderReset) {
+			f.logger.Warnf("cursor of %s was reset, listing the folder again", path)
+			if cursor, known, err = f.watchReset(ctx, path, options, cursor, known, events); err == nil {
+				continue
+			}
+		}
+
+		f.logger.WithField("error", err).Errorf("error watching changes on %s", path)
+
+		if errors.As(err, &apiError) && !apiError.Retryable() {
+			select {
+			case events <- WatchEvent{Err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		if !wait(ctx, watchRetryDelay) {
+			return
+		}
+	}
+}
+
+// watchChanges waits for changes on the cursor and emits them, returning the cursor after the changes emitted and the backoff
+// requested by the server. on error, the cursor returned is the one of the last changes emitted
+func (f *Folder) watchChanges(ctx context.Context, cursor string, known map[string]metadata, events chan<- WatchEvent) (string, time.Duration, error) {
+	longpoll, err := f.longpoll(ctx, cursor)
+	if err != nil {
+		return cursor, 0, err
+	}
+
+	for hasMore := longpoll.Changes; hasMore; {
+		response, err := f.ListContinue(cursor)
+		if err != nil {
+			return cursor, 0, err
+		}
+
+		for _, entry := range response.Entries {
+			event := WatchEvent{Entry: entry}
+
+			switch _, ok := known[entry.PathLower]; {
+			case entry.IsDeleted():
+				event.Type = WatchEventDeleted
+				for path := range known {
+					if path == entry.PathLower || strings.HasPrefix(path, entry.PathLower+"/") {
+						delete(known, path)
+					}
+				}
+			case ok:
+				event.Type = WatchEventModified
+				known[entry.PathLower] = entry
+			default:
+				event.Type = WatchEventAdded
+				known[entry.PathLower] = entry
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return cursor, 0, ctx.Err()
+			}
+		}
+
+		cursor = response.Cursor
+		hasMore = response.HasMore
+	}
+
+	return cursor, time.Duration(longpoll.Backoff) * time.Second, nil
+}
+
+// watchReset lists the folder again, after the cursor was reset, emitting the entries added, modified and deleted since the known entries.
+// on error, the cursor and the entries are returned as they were, to reset them again on the next attempt
+func (f *Folder) watchReset(ctx context.Context, path string, options []ListFolderOption, cursor string, known map[string]metadata, events chan<- WatchEvent) (string, map[string]metadata, error) {
+	newCursor, current, err := f.watchList(path, options...)
+	if err != nil {
+		return cursor, known, err
+	}
+
+	changes := make([]WatchEvent, 0)
+	for entryPath, entry := range current {
+		switch previous, ok := known[entryPath]; {
+		case !ok:
+			changes = append(changes, WatchEvent{Type: WatchEventAdded, Entry: entry})
+		case entryChanged(previous, entry):
+			changes = append(changes, WatchEvent{Type: WatchEventModified, Entry: entry})
+		}
+	}
+	for entryPath, entry := range known {
+		if _, ok := current[entryPath]; !ok {
+			entry.Tag = metadataTagDeleted
+			changes = append(changes, WatchEvent{Type: WatchEventDeleted, Entry: entry})
+		}
+	}
+
+	for _, event := range changes {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return cursor, known, ctx.Err()
+		}
+	}
+
+	return newCursor, current, nil
+}
+
+// entryChanged reports if the entry changed between two listings, by its type, revision, content hash or modification time
+func entryChanged(previous, current metadata) bool {
+	return previous.Tag != current.Tag ||
+		previous.Rev != current.Rev ||
+		previous.ContentHash != current.ContentHash ||
+		!previous.ServerModified.Equal(current.ServerModified)
+}
+
+func (f *Folder) longpoll(ctx context.Context, cursor string) (*listFolderLongpollResponse, error) {
+	body, err := json.Marshal(listFolderLongpollRequest{
+		Cursor:  cursor,
+		Timeout: longpollTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	host := f.config.Hosts.Notify
+	if host == "" {
+		host = defaultNotifyHost
+	}
+
+	// the long poll endpoint does not accept the authorization header
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, host+"/files/list_folder/longpoll", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", string(web.ContentTypeApplicationJSON))
+
+	response, err := f.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(response.Body)
+		return nil, newApiError(response.StatusCode, message)
+	}
+
+	dropboxResponse := &listFolderLongpollResponse{}
+	if err := json.NewDecoder(response.Body).Decode(dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
@@ -0,0 +1,103 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeWatchServer resets the first cursor of the folder, and rejects the cursor of the second listing as invalid
+type fakeWatchServer struct {
+	mu    sync.Mutex
+	lists int
+}
+
+func (s *fakeWatchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request := listFolderContinueRequest{}
+	json.NewDecoder(r.Body).Decode(&request)
+
+	switch r.URL.Path {
+	case "/files/list_folder":
+		s.lists++
+		entries := []metadata{
+			{Tag: metadataTagFile, PathLower: "/folder/a.txt", Rev: "a1"},
+			{Tag: metadataTagFile, PathLower: "/folder/b.txt", Rev: "b1"},
+			{Tag: metadataTagFile, PathLower: "/folder/d.txt", Rev: "d1"},
+		}
+		if s.lists > 1 {
+			// a.txt is deleted, b.txt is overwritten and c.txt is added while the cursor is reset
+			entries = []metadata{
+				{Tag: metadataTagFile, PathLower: "/folder/b.txt", Rev: "b2"},
+				{Tag: metadataTagFile, PathLower: "/folder/c.txt", Rev: "c1"},
+				{Tag: metadataTagFile, PathLower: "/folder/d.txt", Rev: "d1"},
+			}
+		}
+		json.NewEncoder(w).Encode(listFolderResponse{Entries: entries, Cursor: fmt.Sprintf("cursor-%d", s.lists)})
+
+	case "/files/list_folder/longpoll":
+		if request.Cursor == "cursor-1" {
+			w.Write([]byte(`{"changes": true}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_summary": "invalid_cursor"}`))
+
+	case "/files/list_folder/continue":
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_summary": "reset/...", "error": {".tag": "reset"}}`))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestWatchReset(t *testing.T) {
+	service := newTestDropbox(t, &fakeWatchServer{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := service.Folder().Watch(ctx, "/folder")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	changes := make(map[string]WatchEventType)
+	var watchErr error
+	for event := range events {
+		if event.Err != nil {
+			watchErr = event.Err
+			continue
+		}
+		changes[event.Entry.PathLower] = event.Type
+		if event.Type == WatchEventModified && event.Entry.Rev != "b2" {
+			t.Errorf("modified entry with revision %s instead of b2", event.Entry.Rev)
+		}
+	}
+
+	expected := map[string]WatchEventType{
+		"/folder/a.txt": WatchEventDeleted,
+		"/folder/b.txt": WatchEventModified,
+		"/folder/c.txt": WatchEventAdded,
+	}
+	if fmt.Sprint(changes) != fmt.Sprint(expected) {
+		t.Errorf("changes %v instead of %v", changes, expected)
+	}
+
+	var apiError *ApiError
+	if !errors.As(watchErr, &apiError) || apiError.Status != http.StatusBadRequest {
+		t.Errorf("error %v instead of the invalid cursor", watchErr)
+	}
+
+	if ctx.Err() != nil {
+		t.Errorf("the watch did not stop on the invalid cursor")
+	}
+}
@@ -0,0 +1,92 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/joaosoft/logger"
+	"github.com/joaosoft/manager"
+)
+
+const (
+	jobTagAsyncJobID = "async_job_id"
+	jobTagInProgress = "in_progress"
+	jobTagComplete   = "complete"
+	jobTagFailed     = "failed"
+)
+
+type asyncJobRequest struct {
+	AsyncJobID string `json:"async_job_id"`
+}
+
+type asyncJobStatus struct {
+	Tag        string          `json:".tag"`
+	AsyncJobID string          `json:"async_job_id,omitempty"`
+	Failed     json.RawMessage `json:"failed,omitempty"`
+}
+
+// waitJob waits for the result of a call that may run as an async job.
+// when the launch response has an async job id, the check endpoint is polled until the job is no longer in progress
+func waitJob(ctx context.Context, client manager.IGateway, config *DropboxConfig, logger logger.ILogger, checkEndpoint string, launch json.RawMessage) (json.RawMessage, error) {
+	status := asyncJobStatus{}
+	if err := json.Unmarshal(launch, &status); err != nil {
+		err = logger.Error("errors converting async job response data").ToError()
+		return nil, err
+	}
+
+	if status.Tag != jobTagAsyncJobID {
+		return launch, nil
+	}
+
+	return pollJob(ctx, client, config, logger, checkEndpoint, status.AsyncJobID)
+}
+
+// pollJob polls the check endpoint of an async job until it is no longer in progress, returning its final status
+func pollJob(ctx context.Context, client manager.IGateway, config *DropboxConfig, logger logger.ILogger, checkEndpoint string, asyncJobID string) (json.RawMessage, error) {
+	for {
+		var response json.RawMessage
+		if err := rpcRequest(client, config, logger, checkEndpoint, asyncJobRequest{AsyncJobID: asyncJobID}, &response); err != nil {
+			return nil, err
+		}
+
+		status := asyncJobStatus{}
+		if err := json.Unmarshal(response, &status); err != nil {
+			err = logger.Error("errors converting async job response data").ToError()
+			return nil, err
+		}
+
+		switch status.Tag {
+		case jobTagInProgress:
+		case jobTagFailed:
+			return nil, &JobError{AsyncJobID: asyncJobID, Summary: unionSummary(status.Failed)}
+		default:
+			return response, nil
+		}
+
+		if !wait(ctx, jobPollInterval) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// unionSummary returns the chain of tags of a dropbox union, like path_lookup/not_found
+func unionSummary(union json.RawMessage) string {
+	tags := make([]string, 0)
+
+	for len(union) > 0 {
+		var value map[string]json.RawMessage
+		if err := json.Unmarshal(union, &value); err != nil {
+			break
+		}
+
+		var tag string
+		if err := json.Unmarshal(value[".tag"], &tag); err != nil || tag == "" {
+			break
+		}
+		tags = append(tags, tag)
+		union = value[tag]
+	}
+
+	return strings.Join(tags, "/")
+}
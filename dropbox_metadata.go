@@ -0,0 +1,82 @@
+package dropbox
+
+import "time"
+
+const (
+	metadataTagFile    = "file"
+	metadataTagFolder  = "folder"
+	metadataTagDeleted = "deleted"
+)
+
+type metadata struct {
+	Tag            string    `json:".tag"`
+	Name           string    `json:"name"`
+	ID             string    `json:"id"`
+	ClientModified time.Time `json:"client_modified,omitempty"`
+	ServerModified time.Time `json:"server_modified,omitempty"`
+	Rev            string    `json:"rev,omitempty"`
+	Size           int       `json:"size,omitempty"`
+	PathLower      string    `json:"path_lower"`
+	PathDisplay    string    `json:"path_display"`
+	SharedFolderID string    `json:"shared_folder_id,omitempty"`
+	SharingInfo    struct {
+		ReadOnly             bool   `json:"read_only"`
+		ParentSharedFolderID string `json:"parent_shared_folder_id"`
+		ModifiedBy           string `json:"modified_by"`
+		SharedFolderID       string `json:"shared_folder_id,omitempty"`
+		TraverseOnly         bool   `json:"traverse_only,omitempty"`
+		NoAccess             bool   `json:"no_access,omitempty"`
+	} `json:"sharing_info"`
+	PropertyGroups []struct {
+		TemplateID string `json:"template_id"`
+		Fields     []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"fields"`
+	} `json:"property_groups"`
+	MediaInfo                *mediaInfo `json:"media_info,omitempty"`
+	IsDownloadable           bool       `json:"is_downloadable,omitempty"`
+	HasExplicitSharedMembers bool       `json:"has_explicit_shared_members,omitempty"`
+	ContentHash              string     `json:"content_hash,omitempty"`
+}
+
+// IsFile ...
+func (m *metadata) IsFile() bool {
+	return m.Tag == metadataTagFile
+}
+
+// IsFolder ...
+func (m *metadata) IsFolder() bool {
+	return m.Tag == metadataTagFolder
+}
+
+// IsDeleted ...
+func (m *metadata) IsDeleted() bool {
+	return m.Tag == metadataTagDeleted
+}
+
+type mediaInfo struct {
+	// Tag is pending while the media information is still being processed, otherwise metadata
+	Tag      string         `json:".tag"`
+	Metadata *mediaMetadata `json:"metadata,omitempty"`
+}
+
+type mediaMetadata struct {
+	// Tag is photo or video
+	Tag        string `json:".tag"`
+	Dimensions *struct {
+		Height int `json:"height"`
+		Width  int `json:"width"`
+	} `json:"dimensions,omitempty"`
+	Location *struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"location,omitempty"`
+	TimeTaken *time.Time `json:"time_taken,omitempty"`
+	Duration  int64      `json:"duration,omitempty"`
+}
+
+// tag is a dropbox union without value, like {".tag": "image"}
+type tag struct {
+	Tag string `json:".tag"`
+}
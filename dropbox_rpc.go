@@ -0,0 +1,48 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/joaosoft/logger"
+	"github.com/joaosoft/manager"
+	"github.com/joaosoft/web"
+)
+
+// rpcRequest executes a request to the api host, with the arguments and the response as json.
+// the response is ignored when dropboxResponse is nil
+func rpcRequest(client manager.IGateway, config *DropboxConfig, logger logger.ILogger, endpoint string, request interface{}, dropboxResponse interface{}) error {
+	return rpcHostRequest(client, config, logger, config.Hosts.Api, endpoint, request, dropboxResponse)
+}
+
+// rpcHostRequest executes a request like rpcRequest, to the given host
+func rpcHostRequest(client manager.IGateway, config *DropboxConfig, logger logger.ILogger, host, endpoint string, request interface{}, dropboxResponse interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		err = logger.Error("errors marshal arguments").ToError()
+		return err
+	}
+
+	headers := manager.Headers{
+		"Authorization": {fmt.Sprintf("%s %s", config.Authorization.Access, config.Authorization.Token)},
+	}
+
+	if status, response, err := client.Request(http.MethodPost, host, endpoint, string(web.ContentTypeApplicationJSON), headers, body); err != nil {
+		err = logger.WithField("response", response).Errorf("errors calling %s", endpoint).ToError()
+		return err
+	} else if status != http.StatusOK {
+		logger.WithField("response", response).Errorf("response status %d instead of %d", status, http.StatusOK)
+		return newApiError(status, response)
+	} else if response == nil {
+		err = logger.Errorf("errors calling %s", endpoint).ToError()
+		return err
+	} else if dropboxResponse != nil {
+		if err := json.Unmarshal(response, dropboxResponse); err != nil {
+			err = logger.Errorf("errors converting %s response data", endpoint).ToError()
+			return err
+		}
+	}
+
+	return nil
+}
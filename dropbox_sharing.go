@@ -0,0 +1,223 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/joaosoft/logger"
+	"github.com/joaosoft/manager"
+)
+
+// LinkAudience ...
+type LinkAudience string
+
+const (
+	LinkAudiencePublic LinkAudience = "public"
+	LinkAudienceTeam   LinkAudience = "team"
+	LinkAudienceNoOne  LinkAudience = "no_one"
+)
+
+// LinkAccessLevel ...
+type LinkAccessLevel string
+
+const (
+	LinkAccessLevelViewer LinkAccessLevel = "viewer"
+	LinkAccessLevelEditor LinkAccessLevel = "editor"
+	LinkAccessLevelMax    LinkAccessLevel = "max"
+)
+
+type Sharing struct {
+	client     manager.IGateway
+	httpClient *http.Client
+	config     *DropboxConfig
+	logger     logger.ILogger
+}
+
+type sharedLinkSettings struct {
+	RequirePassword *bool      `json:"require_password,omitempty"`
+	LinkPassword    string     `json:"link_password,omitempty"`
+	Expires         *time.Time `json:"expires,omitempty"`
+	Audience        *tag       `json:"audience,omitempty"`
+	Access          *tag       `json:"access,omitempty"`
+	AllowDownload   *bool      `json:"allow_download,omitempty"`
+}
+
+func newSharedLinkSettings(options ...SharedLinkOption) sharedLinkSettings {
+	settings := sharedLinkSettings{}
+
+	for _, option := range options {
+		option(&settings)
+	}
+
+	return settings
+}
+
+type sharedLinkMetadata struct {
+	// Tag is file or folder
+	Tag             string     `json:".tag"`
+	URL             string     `json:"url"`
+	Name            string     `json:"name"`
+	ID              string     `json:"id,omitempty"`
+	Expires         *time.Time `json:"expires,omitempty"`
+	PathLower       string     `json:"path_lower,omitempty"`
+	ClientModified  time.Time  `json:"client_modified,omitempty"`
+	ServerModified  time.Time  `json:"server_modified,omitempty"`
+	Rev             string     `json:"rev,omitempty"`
+	Size            int        `json:"size,omitempty"`
+	LinkPermissions struct {
+		CanRevoke                     bool `json:"can_revoke"`
+		ResolvedVisibility            *tag `json:"resolved_visibility,omitempty"`
+		RequestedVisibility           *tag `json:"requested_visibility,omitempty"`
+		RevokeFailureReason           *tag `json:"revoke_failure_reason,omitempty"`
+		EffectiveAudience             *tag `json:"effective_audience,omitempty"`
+		LinkAccessLevel               *tag `json:"link_access_level,omitempty"`
+		AllowDownload                 bool `json:"allow_download"`
+		CanSetExpiry                  bool `json:"can_set_expiry"`
+		CanRemoveExpiry               bool `json:"can_remove_expiry"`
+		CanUseExtendedSharingControls bool `json:"can_use_extended_sharing_controls"`
+	} `json:"link_permissions"`
+	TeamMemberInfo *struct {
+		TeamInfo struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"team_info"`
+		DisplayName string `json:"display_name"`
+		MemberID    string `json:"member_id,omitempty"`
+	} `json:"team_member_info,omitempty"`
+}
+
+type createSharedLinkRequest struct {
+	Path     string             `json:"path"`
+	Settings sharedLinkSettings `json:"settings"`
+}
+
+// CreateSharedLink creates a shared link to a file or folder, with the given settings
+func (s *Sharing) CreateSharedLink(path string, options ...SharedLinkOption) (*sharedLinkMetadata, error) {
+	request := createSharedLinkRequest{
+		Path:     path,
+		Settings: newSharedLinkSettings(options...),
+	}
+
+	dropboxResponse := &sharedLinkMetadata{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/create_shared_link_with_settings", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+type listSharedLinksRequest struct {
+	Path       string `json:"path,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
+	DirectOnly bool   `json:"direct_only,omitempty"`
+}
+
+type listSharedLinksResponse struct {
+	Links   []sharedLinkMetadata `json:"links"`
+	HasMore bool                 `json:"has_more"`
+	Cursor  string               `json:"cursor,omitempty"`
+}
+
+// ListSharedLinks lists all the shared links of the path, following the cursor of each page.
+// with an empty path, all the shared links of the user are listed. with directOnly, the links to the parent folders are excluded
+func (s *Sharing) ListSharedLinks(path string, directOnly bool) ([]sharedLinkMetadata, error) {
+	links := make([]sharedLinkMetadata, 0)
+	request := listSharedLinksRequest{
+		Path:       path,
+		DirectOnly: directOnly,
+	}
+
+	for {
+		dropboxResponse := &listSharedLinksResponse{}
+		if err := rpcRequest(s.client, s.config, s.logger, "/sharing/list_shared_links", request, dropboxResponse); err != nil {
+			return nil, err
+		}
+		links = append(links, dropboxResponse.Links...)
+
+		if !dropboxResponse.HasMore {
+			return links, nil
+		}
+		request.Cursor = dropboxResponse.Cursor
+	}
+}
+
+type modifySharedLinkSettingsRequest struct {
+	URL              string             `json:"url"`
+	Settings         sharedLinkSettings `json:"settings"`
+	RemoveExpiration bool               `json:"remove_expiration"`
+}
+
+// ModifySharedLinkSettings changes the settings of a shared link, removing its expiration with removeExpiration
+func (s *Sharing) ModifySharedLinkSettings(url string, removeExpiration bool, options ...SharedLinkOption) (*sharedLinkMetadata, error) {
+	request := modifySharedLinkSettingsRequest{
+		URL:              url,
+		Settings:         newSharedLinkSettings(options...),
+		RemoveExpiration: removeExpiration,
+	}
+
+	dropboxResponse := &sharedLinkMetadata{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/modify_shared_link_settings", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+type revokeSharedLinkRequest struct {
+	URL string `json:"url"`
+}
+
+// RevokeSharedLink revokes a shared link
+func (s *Sharing) RevokeSharedLink(url string) error {
+	return rpcRequest(s.client, s.config, s.logger, "/sharing/revoke_shared_link", revokeSharedLinkRequest{URL: url}, nil)
+}
+
+type sharedLinkRequest struct {
+	URL          string `json:"url"`
+	Path         string `json:"path,omitempty"`
+	LinkPassword string `json:"link_password,omitempty"`
+}
+
+// GetSharedLinkMetadata gets the metadata of the file or folder behind a shared link, with the password of the link when it has one
+func (s *Sharing) GetSharedLinkMetadata(url, password string) (*sharedLinkMetadata, error) {
+	request := sharedLinkRequest{
+		URL:          url,
+		LinkPassword: password,
+	}
+
+	dropboxResponse := &sharedLinkMetadata{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/get_shared_link_metadata", request, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// GetSharedLinkFile downloads a file behind a shared link as a stream, together with its metadata,
+// with the password of the link when it has one. for a link to a folder, the path is the path of the file relative
+// to the folder, otherwise it is empty. the caller is responsible for closing the returned stream
+func (s *Sharing) GetSharedLinkFile(ctx context.Context, url, path, password string) (io.ReadCloser, *sharedLinkMetadata, error) {
+	args := sharedLinkRequest{
+		URL:          url,
+		Path:         path,
+		LinkPassword: password,
+	}
+
+	response, err := contentRequest(ctx, s.httpClient, s.config, "/sharing/get_shared_link_file", args, nil, 0, nil)
+	if err != nil {
+		s.logger.WithField("error", err).Errorf("error downloading shared link file %s", url)
+		return nil, nil, err
+	}
+
+	dropboxResponse := &sharedLinkMetadata{}
+	if err := json.Unmarshal([]byte(response.Header.Get("Dropbox-API-Result")), dropboxResponse); err != nil {
+		response.Body.Close()
+		err = s.logger.Error("errors converting shared link file response metadata").ToError()
+		return nil, nil, err
+	}
+
+	return response.Body, dropboxResponse, nil
+}
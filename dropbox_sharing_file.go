@@ -0,0 +1,198 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type memberActionResult struct {
+	Member Member
+	// Failure is the summary of the error when the action failed for the member, like member_error/invalid_member
+	Failure string
+}
+
+// Success ...
+func (r *memberActionResult) Success() bool {
+	return r.Failure == ""
+}
+
+type fileMemberActionResult struct {
+	Member Member          `json:"member"`
+	Result json.RawMessage `json:"result"`
+}
+
+type addFileMemberRequest struct {
+	File          string   `json:"file"`
+	Members       []Member `json:"members"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+	Quiet         bool     `json:"quiet"`
+	AccessLevel   tag      `json:"access_level"`
+}
+
+// AddFileMember shares a file with members, notifying them with the custom message unless quiet.
+// the file is a path or a file id, and the results are in the same order of the members
+func (s *Sharing) AddFileMember(file string, members []Member, accessLevel AccessLevel, quiet bool, customMessage string) ([]memberActionResult, error) {
+	request := addFileMemberRequest{
+		File:          file,
+		Members:       members,
+		CustomMessage: customMessage,
+		Quiet:         quiet,
+		AccessLevel:   tag{Tag: string(accessLevel)},
+	}
+
+	dropboxResponse := make([]fileMemberActionResult, 0)
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/add_file_member", request, &dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	results := make([]memberActionResult, len(dropboxResponse))
+	for i, entry := range dropboxResponse {
+		results[i].Member = entry.Member
+
+		result := tag{}
+		if err := json.Unmarshal(entry.Result, &result); err != nil || result.Tag != batchTagSuccess {
+			results[i].Failure = unionSummary(entry.Result)
+		}
+	}
+
+	return results, nil
+}
+
+type removeFileMemberRequest struct {
+	File   string `json:"file"`
+	Member Member `json:"member"`
+}
+
+// RemoveFileMember stops sharing a file with a member. the file is a path or a file id
+func (s *Sharing) RemoveFileMember(file string, member Member) error {
+	request := removeFileMemberRequest{
+		File:   file,
+		Member: member,
+	}
+
+	var dropboxResponse json.RawMessage
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/remove_file_member_2", request, &dropboxResponse); err != nil {
+		return err
+	}
+
+	result := tag{}
+	if err := json.Unmarshal(dropboxResponse, &result); err != nil || result.Tag != batchTagSuccess {
+		err = s.logger.Errorf("error removing member from file %s: %s", file, unionSummary(dropboxResponse)).ToError()
+		return err
+	}
+
+	return nil
+}
+
+type listFileMembersRequest struct {
+	File             string `json:"file"`
+	IncludeInherited bool   `json:"include_inherited"`
+}
+
+// ListFileMembers lists all the members of a shared file, following the cursor of each page. the file is a path or a file id
+func (s *Sharing) ListFileMembers(file string) (*sharedMembers, error) {
+	request := listFileMembersRequest{
+		File:             file,
+		IncludeInherited: true,
+	}
+
+	return s.listMembers("/sharing/list_file_members", "/sharing/list_file_members/continue", request)
+}
+
+type listFileMembersBatchRequest struct {
+	Files []string `json:"files"`
+}
+
+type listFileMembersBatchResponse []struct {
+	File   string `json:"file"`
+	Result struct {
+		Tag         string          `json:".tag"`
+		Members     *sharedMembers  `json:"members,omitempty"`
+		MemberCount int             `json:"member_count,omitempty"`
+		AccessError json.RawMessage `json:"access_error,omitempty"`
+	} `json:"result"`
+}
+
+type fileMembersBatchResult struct {
+	File        string
+	Members     *sharedMembers
+	MemberCount int
+	// Failure is the summary of the error when the members of the file could not be listed, like invalid_file
+	Failure string
+}
+
+// Success ...
+func (r *fileMembersBatchResult) Success() bool {
+	return r.Failure == ""
+}
+
+// ListFileMembersBatch lists the members of multiple shared files at once, following the cursor of each file.
+// the files are paths or file ids, and the results are in the same order of the files
+func (s *Sharing) ListFileMembersBatch(files []string) ([]fileMembersBatchResult, error) {
+	dropboxResponse := listFileMembersBatchResponse{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/list_file_members/batch", listFileMembersBatchRequest{Files: files}, &dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	results := make([]fileMembersBatchResult, len(dropboxResponse))
+	for i, entry := range dropboxResponse {
+		results[i].File = entry.File
+		results[i].MemberCount = entry.Result.MemberCount
+
+		if entry.Result.Members == nil {
+			if results[i].Failure = unionSummary(entry.Result.AccessError); results[i].Failure == "" {
+				results[i].Failure = entry.Result.Tag
+			}
+			continue
+		}
+
+		members := entry.Result.Members
+		for cursor := members.Cursor; cursor != ""; {
+			page := &sharedMembers{}
+			if err := rpcRequest(s.client, s.config, s.logger, "/sharing/list_file_members/continue", sharingContinueRequest{Cursor: cursor}, page); err != nil {
+				return nil, err
+			}
+
+			members.Users = append(members.Users, page.Users...)
+			members.Groups = append(members.Groups, page.Groups...)
+			members.Invitees = append(members.Invitees, page.Invitees...)
+			cursor = page.Cursor
+		}
+		members.Cursor = ""
+		results[i].Members = members
+	}
+
+	return results, nil
+}
+
+type getFileMetadataRequest struct {
+	File string `json:"file"`
+}
+
+type sharedFileMetadata struct {
+	ID                   string     `json:"id"`
+	Name                 string     `json:"name"`
+	PathLower            string     `json:"path_lower,omitempty"`
+	PathDisplay          string     `json:"path_display,omitempty"`
+	ParentSharedFolderID string     `json:"parent_shared_folder_id,omitempty"`
+	PreviewURL           string     `json:"preview_url"`
+	AccessType           *tag       `json:"access_type,omitempty"`
+	OwnerDisplayNames    []string   `json:"owner_display_names,omitempty"`
+	TimeInvited          *time.Time `json:"time_invited,omitempty"`
+	Policy               struct {
+		AclUpdatePolicy  tag  `json:"acl_update_policy"`
+		SharedLinkPolicy tag  `json:"shared_link_policy"`
+		MemberPolicy     *tag `json:"member_policy,omitempty"`
+		ViewerInfoPolicy *tag `json:"viewer_info_policy,omitempty"`
+	} `json:"policy"`
+}
+
+// GetFileMetadata gets the sharing metadata of a file. the file is a path or a file id
+func (s *Sharing) GetFileMetadata(file string) (*sharedFileMetadata, error) {
+	dropboxResponse := &sharedFileMetadata{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/get_file_metadata", getFileMetadataRequest{File: file}, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
@@ -0,0 +1,352 @@
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AccessLevel ...
+type AccessLevel string
+
+const (
+	AccessLevelOwner           AccessLevel = "owner"
+	AccessLevelEditor          AccessLevel = "editor"
+	AccessLevelViewer          AccessLevel = "viewer"
+	AccessLevelViewerNoComment AccessLevel = "viewer_no_comment"
+	AccessLevelTraverse        AccessLevel = "traverse"
+	AccessLevelNoAccess        AccessLevel = "no_access"
+)
+
+const (
+	memberTagEmail     = "email"
+	memberTagDropboxID = "dropbox_id"
+)
+
+// Member selects a member by email or by dropbox id
+type Member struct {
+	Email     string
+	DropboxID string
+}
+
+// MarshalJSON ...
+func (m Member) MarshalJSON() ([]byte, error) {
+	if m.DropboxID != "" {
+		return json.Marshal(struct {
+			Tag       string `json:".tag"`
+			DropboxID string `json:"dropbox_id"`
+		}{Tag: memberTagDropboxID, DropboxID: m.DropboxID})
+	}
+
+	return json.Marshal(struct {
+		Tag   string `json:".tag"`
+		Email string `json:"email"`
+	}{Tag: memberTagEmail, Email: m.Email})
+}
+
+// UnmarshalJSON ...
+func (m *Member) UnmarshalJSON(data []byte) error {
+	member := struct {
+		Tag       string `json:".tag"`
+		Email     string `json:"email"`
+		DropboxID string `json:"dropbox_id"`
+	}{}
+
+	if err := json.Unmarshal(data, &member); err != nil {
+		return err
+	}
+
+	m.Email = member.Email
+	m.DropboxID = member.DropboxID
+
+	return nil
+}
+
+// MemberAccess is a member with the access level to give to it
+type MemberAccess struct {
+	Member      Member
+	AccessLevel AccessLevel
+}
+
+type addMember struct {
+	Member      Member `json:"member"`
+	AccessLevel tag    `json:"access_level"`
+}
+
+func newAddMembers(members []MemberAccess) []addMember {
+	addMembers := make([]addMember, len(members))
+	for i, member := range members {
+		addMembers[i] = addMember{
+			Member:      member.Member,
+			AccessLevel: tag{Tag: string(member.AccessLevel)},
+		}
+	}
+
+	return addMembers
+}
+
+type userMembershipInfo struct {
+	AccessType tag `json:"access_type"`
+	User       struct {
+		AccountID    string `json:"account_id"`
+		Email        string `json:"email"`
+		DisplayName  string `json:"display_name"`
+		SameTeam     bool   `json:"same_team"`
+		TeamMemberID string `json:"team_member_id,omitempty"`
+	} `json:"user"`
+	IsInherited  bool       `json:"is_inherited"`
+	TimeLastSeen *time.Time `json:"time_last_seen,omitempty"`
+}
+
+type groupMembershipInfo struct {
+	AccessType tag `json:"access_type"`
+	Group      struct {
+		GroupName           string `json:"group_name"`
+		GroupID             string `json:"group_id"`
+		GroupManagementType tag    `json:"group_management_type"`
+		GroupType           tag    `json:"group_type"`
+		IsMember            bool   `json:"is_member"`
+		IsOwner             bool   `json:"is_owner"`
+		SameTeam            bool   `json:"same_team"`
+		MemberCount         int    `json:"member_count,omitempty"`
+	} `json:"group"`
+	IsInherited bool `json:"is_inherited"`
+}
+
+type inviteeMembershipInfo struct {
+	AccessType tag `json:"access_type"`
+	Invitee    struct {
+		Tag   string `json:".tag"`
+		Email string `json:"email,omitempty"`
+	} `json:"invitee"`
+	IsInherited bool `json:"is_inherited"`
+}
+
+type sharedFolderMetadata struct {
+	AccessType           tag        `json:"access_type"`
+	IsInsideTeamFolder   bool       `json:"is_inside_team_folder"`
+	IsTeamFolder         bool       `json:"is_team_folder"`
+	Name                 string     `json:"name"`
+	PathLower            string     `json:"path_lower,omitempty"`
+	PathDisplay          string     `json:"path_display,omitempty"`
+	ParentSharedFolderID string     `json:"parent_shared_folder_id,omitempty"`
+	SharedFolderID       string     `json:"shared_folder_id"`
+	PreviewURL           string     `json:"preview_url"`
+	TimeInvited          *time.Time `json:"time_invited,omitempty"`
+	OwnerDisplayNames    []string   `json:"owner_display_names,omitempty"`
+	AccessInheritance    *tag       `json:"access_inheritance,omitempty"`
+	Policy               struct {
+		AclUpdatePolicy  tag  `json:"acl_update_policy"`
+		SharedLinkPolicy tag  `json:"shared_link_policy"`
+		MemberPolicy     *tag `json:"member_policy,omitempty"`
+		ViewerInfoPolicy *tag `json:"viewer_info_policy,omitempty"`
+	} `json:"policy"`
+}
+
+type shareFolderRequest struct {
+	Path             string `json:"path"`
+	ForceAsync       bool   `json:"force_async"`
+	AclUpdatePolicy  *tag   `json:"acl_update_policy,omitempty"`
+	MemberPolicy     *tag   `json:"member_policy,omitempty"`
+	SharedLinkPolicy *tag   `json:"shared_link_policy,omitempty"`
+}
+
+// ShareFolder shares a folder, waiting for the async job to finish when it runs asynchronously
+func (s *Sharing) ShareFolder(ctx context.Context, path string, options ...ShareFolderOption) (*sharedFolderMetadata, error) {
+	request := shareFolderRequest{
+		Path:       path,
+		ForceAsync: false,
+	}
+
+	for _, option := range options {
+		option(&request)
+	}
+
+	response, err := s.launchJob(ctx, "/sharing/share_folder", "/sharing/check_share_job_status", request)
+	if err != nil {
+		return nil, err
+	}
+
+	dropboxResponse := &sharedFolderMetadata{}
+	if err := json.Unmarshal(response, dropboxResponse); err != nil {
+		err = s.logger.Error("errors converting share folder response data").ToError()
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+type unshareFolderRequest struct {
+	SharedFolderID string `json:"shared_folder_id"`
+	LeaveACopy     bool   `json:"leave_a_copy"`
+}
+
+// UnshareFolder stops sharing a folder, waiting for the async job to finish.
+// with leaveACopy, the members keep a copy of the folder in their dropbox
+func (s *Sharing) UnshareFolder(ctx context.Context, sharedFolderID string, leaveACopy bool) error {
+	request := unshareFolderRequest{
+		SharedFolderID: sharedFolderID,
+		LeaveACopy:     leaveACopy,
+	}
+
+	_, err := s.launchJob(ctx, "/sharing/unshare_folder", "/sharing/check_job_status", request)
+	return err
+}
+
+type addFolderMemberRequest struct {
+	SharedFolderID string      `json:"shared_folder_id"`
+	Members        []addMember `json:"members"`
+	Quiet          bool        `json:"quiet"`
+	CustomMessage  string      `json:"custom_message,omitempty"`
+}
+
+// AddFolderMember invites members to a shared folder, notifying them with the custom message unless quiet
+func (s *Sharing) AddFolderMember(sharedFolderID string, members []MemberAccess, quiet bool, customMessage string) error {
+	request := addFolderMemberRequest{
+		SharedFolderID: sharedFolderID,
+		Members:        newAddMembers(members),
+		Quiet:          quiet,
+		CustomMessage:  customMessage,
+	}
+
+	return rpcRequest(s.client, s.config, s.logger, "/sharing/add_folder_member", request, nil)
+}
+
+type removeFolderMemberRequest struct {
+	SharedFolderID string `json:"shared_folder_id"`
+	Member         Member `json:"member"`
+	LeaveACopy     bool   `json:"leave_a_copy"`
+}
+
+// RemoveFolderMember removes a member from a shared folder, waiting for the async job to finish.
+// with leaveACopy, the member keeps a copy of the folder in its dropbox
+func (s *Sharing) RemoveFolderMember(ctx context.Context, sharedFolderID string, member Member, leaveACopy bool) error {
+	request := removeFolderMemberRequest{
+		SharedFolderID: sharedFolderID,
+		Member:         member,
+		LeaveACopy:     leaveACopy,
+	}
+
+	_, err := s.launchJob(ctx, "/sharing/remove_folder_member", "/sharing/check_remove_member_job_status", request)
+	return err
+}
+
+type updateFolderMemberRequest struct {
+	SharedFolderID string `json:"shared_folder_id"`
+	Member         Member `json:"member"`
+	AccessLevel    tag    `json:"access_level"`
+}
+
+// UpdateFolderMember changes the access level of a member of a shared folder
+func (s *Sharing) UpdateFolderMember(sharedFolderID string, member Member, accessLevel AccessLevel) error {
+	request := updateFolderMemberRequest{
+		SharedFolderID: sharedFolderID,
+		Member:         member,
+		AccessLevel:    tag{Tag: string(accessLevel)},
+	}
+
+	return rpcRequest(s.client, s.config, s.logger, "/sharing/update_folder_member", request, nil)
+}
+
+type listFolderMembersRequest struct {
+	SharedFolderID string `json:"shared_folder_id"`
+}
+
+type sharingContinueRequest struct {
+	Cursor string `json:"cursor"`
+}
+
+type sharedMembers struct {
+	Users    []userMembershipInfo    `json:"users"`
+	Groups   []groupMembershipInfo   `json:"groups"`
+	Invitees []inviteeMembershipInfo `json:"invitees"`
+	Cursor   string                  `json:"cursor,omitempty"`
+}
+
+// ListFolderMembers lists all the members of a shared folder, following the cursor of each page
+func (s *Sharing) ListFolderMembers(sharedFolderID string) (*sharedMembers, error) {
+	return s.listMembers("/sharing/list_folder_members", "/sharing/list_folder_members/continue", listFolderMembersRequest{SharedFolderID: sharedFolderID})
+}
+
+type sharedFolderRequest struct {
+	SharedFolderID string `json:"shared_folder_id"`
+}
+
+// MountFolder mounts a shared folder that the user was invited to, returning its metadata
+func (s *Sharing) MountFolder(sharedFolderID string) (*sharedFolderMetadata, error) {
+	dropboxResponse := &sharedFolderMetadata{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/mount_folder", sharedFolderRequest{SharedFolderID: sharedFolderID}, dropboxResponse); err != nil {
+		return nil, err
+	}
+
+	return dropboxResponse, nil
+}
+
+// UnmountFolder unmounts a shared folder, keeping the access to mount it again
+func (s *Sharing) UnmountFolder(sharedFolderID string) error {
+	return rpcRequest(s.client, s.config, s.logger, "/sharing/unmount_folder", sharedFolderRequest{SharedFolderID: sharedFolderID}, nil)
+}
+
+type listSharedFoldersRequest struct {
+	Limit int `json:"limit,omitempty"`
+}
+
+type listSharedFoldersResponse struct {
+	Entries []sharedFolderMetadata `json:"entries"`
+	Cursor  string                 `json:"cursor,omitempty"`
+}
+
+// ListFolders lists all the shared folders the user has access to, following the cursor of each page
+func (s *Sharing) ListFolders() ([]sharedFolderMetadata, error) {
+	folders := make([]sharedFolderMetadata, 0)
+
+	dropboxResponse := &listSharedFoldersResponse{}
+	if err := rpcRequest(s.client, s.config, s.logger, "/sharing/list_folders", listSharedFoldersRequest{}, dropboxResponse); err != nil {
+		return nil, err
+	}
+	folders = append(folders, dropboxResponse.Entries...)
+
+	for dropboxResponse.Cursor != "" {
+		cursor := dropboxResponse.Cursor
+		dropboxResponse = &listSharedFoldersResponse{}
+		if err := rpcRequest(s.client, s.config, s.logger, "/sharing/list_folders/continue", sharingContinueRequest{Cursor: cursor}, dropboxResponse); err != nil {
+			return nil, err
+		}
+		folders = append(folders, dropboxResponse.Entries...)
+	}
+
+	return folders, nil
+}
+
+// listMembers lists all the members of a shared file or folder, following the cursor of each page
+func (s *Sharing) listMembers(endpoint, continueEndpoint string, request interface{}) (*sharedMembers, error) {
+	members := &sharedMembers{}
+	if err := rpcRequest(s.client, s.config, s.logger, endpoint, request, members); err != nil {
+		return nil, err
+	}
+
+	for cursor := members.Cursor; cursor != ""; {
+		dropboxResponse := &sharedMembers{}
+		if err := rpcRequest(s.client, s.config, s.logger, continueEndpoint, sharingContinueRequest{Cursor: cursor}, dropboxResponse); err != nil {
+			return nil, err
+		}
+
+		members.Users = append(members.Users, dropboxResponse.Users...)
+		members.Groups = append(members.Groups, dropboxResponse.Groups...)
+		members.Invitees = append(members.Invitees, dropboxResponse.Invitees...)
+		cursor = dropboxResponse.Cursor
+	}
+	members.Cursor = ""
+
+	return members, nil
+}
+
+// launchJob calls an endpoint that may run as an async job, waiting for it to finish
+func (s *Sharing) launchJob(ctx context.Context, endpoint, checkEndpoint string, request interface{}) (json.RawMessage, error) {
+	var launch json.RawMessage
+	if err := rpcRequest(s.client, s.config, s.logger, endpoint, request, &launch); err != nil {
+		return nil, err
+	}
+
+	return waitJob(ctx, s.client, s.config, s.logger, checkEndpoint, launch)
+}
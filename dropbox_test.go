@@ -0,0 +1,67 @@
+package dropbox
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joaosoft/logger"
+)
+
+// testGateway is a gateway on the standard http client, able to call the local fake servers
+type testGateway struct {
+	client *http.Client
+}
+
+// Request ...
+func (g *testGateway) Request(method, host, endpoint string, contentType string, headers map[string][]string, body []byte) (int, []byte, error) {
+	request, err := http.NewRequest(method, host+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for key, values := range headers {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := g.client.Do(request)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	return response.StatusCode, data, err
+}
+
+// newTestDropbox creates a dropbox client with every host on a local fake server with the given handler
+func newTestDropbox(t *testing.T, handler http.Handler, options ...DropboxOption) *Dropbox {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := &DropboxConfig{}
+	config.Authorization.Access = "Bearer"
+	config.Authorization.Token = "token"
+	config.Hosts.Api = server.URL
+	config.Hosts.Content = server.URL
+	config.Hosts.Notify = server.URL
+
+	options = append([]DropboxOption{
+		WithConfiguration(config),
+		WithGateway(&testGateway{client: server.Client()}),
+		WithHttpClient(server.Client()),
+		WithLogLevel(logger.NoneLevel),
+	}, options...)
+
+	service, err := NewDropbox(options...)
+	if err != nil {
+		t.Fatalf("error creating dropbox: %s", err)
+	}
+
+	return service
+}
@@ -86,6 +86,4 @@ func (u *User) Get() (*getUserResponse, error) {
 		}
 		return dropboxResponse, nil
 	}
-
-	return nil, nil
 }
@@ -0,0 +1,97 @@
+package dropbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by the api errors of paths that do not exist
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by the api errors of writes that conflict with the content on the server,
+	// like uploads on update mode with an outdated revision
+	ErrConflict = errors.New("conflict")
+)
+
+// ApiError is an error response of the dropbox api
+type ApiError struct {
+	Status  int    `json:"-"`
+	Summary string `json:"error_summary"`
+	Body    string `json:"-"`
+}
+
+func newApiError(status int, response []byte) *ApiError {
+	apiError := &ApiError{
+		Status: status,
+		Body:   string(response),
+	}
+	_ = json.Unmarshal(response, apiError)
+
+	return apiError
+}
+
+// Error ...
+func (e *ApiError) Error() string {
+	if e.Summary == "" {
+		return fmt.Sprintf("response status %d: %s", e.Status, e.Body)
+	}
+	return fmt.Sprintf("response status %d: %s", e.Status, e.Summary)
+}
+
+// Is ...
+func (e *ApiError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return strings.Contains(e.Summary, "not_found/")
+	case ErrConflict:
+		return strings.Contains(e.Summary, "conflict/")
+	}
+	return false
+}
+
+// Retryable reports if the request can be retried as it is, after rate limits and server errors
+func (e *ApiError) Retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= http.StatusInternalServerError
+}
+
+// JobError is the failure of an async job
+type JobError struct {
+	AsyncJobID string
+	Summary    string
+}
+
+// Error ...
+func (e *JobError) Error() string {
+	return fmt.Sprintf("async job %s failed: %s", e.AsyncJobID, e.Summary)
+}
+
+// JobTimeoutError is returned when an async job does not finish before the timeout, with its id to keep checking it
+type JobTimeoutError struct {
+	AsyncJobID string
+	Err        error
+}
+
+// Error ...
+func (e *JobTimeoutError) Error() string {
+	return fmt.Sprintf("async job %s did not finish: %s", e.AsyncJobID, e.Err)
+}
+
+// Unwrap ...
+func (e *JobTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// IntegrityError is returned when the content hash of the transferred content does not match the one of the server
+type IntegrityError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// Error ...
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("content hash %s of %s does not match %s", e.Actual, e.Path, e.Expected)
+}
@@ -1,6 +1,9 @@
 package dropbox
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/joaosoft/logger"
 	"github.com/joaosoft/manager"
 )
@@ -43,3 +46,356 @@ func WithManager(mgr *manager.Manager) DropboxOption {
 		dropbox.pm = mgr
 	}
 }
+
+// WithChunkSize sets the size of each chunk sent on an upload session, clamped to the 150 MB accepted by each request.
+// a size that is not positive is ignored
+func WithChunkSize(size int) DropboxOption {
+	return func(dropbox *Dropbox) {
+		if size > maxUploadSize {
+			size = maxUploadSize
+		}
+
+		if size > 0 {
+			dropbox.chunkSize = size
+		}
+	}
+}
+
+// WithGateway ...
+func WithGateway(client manager.IGateway) DropboxOption {
+	return func(dropbox *Dropbox) {
+		dropbox.client = client
+	}
+}
+
+// WithHttpClient ...
+func WithHttpClient(client *http.Client) DropboxOption {
+	return func(dropbox *Dropbox) {
+		dropbox.httpClient = client
+	}
+}
+
+// ListFolderOption ...
+type ListFolderOption func(request *listFolderRequest)
+
+// WithListRecursive ...
+func WithListRecursive(recursive bool) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.Recursive = recursive
+	}
+}
+
+// WithListIncludeDeleted ...
+func WithListIncludeDeleted(includeDeleted bool) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.IncludeDeleted = includeDeleted
+	}
+}
+
+// WithListIncludeMediaInfo ...
+func WithListIncludeMediaInfo(includeMediaInfo bool) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.IncludeMediaInfo = includeMediaInfo
+	}
+}
+
+// WithListIncludeHasExplicitSharedMembers ...
+func WithListIncludeHasExplicitSharedMembers(includeHasExplicitSharedMembers bool) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.IncludeHasExplicitSharedMembers = includeHasExplicitSharedMembers
+	}
+}
+
+// WithListIncludeMountedFolders ...
+func WithListIncludeMountedFolders(includeMountedFolders bool) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.IncludeMountedFolders = includeMountedFolders
+	}
+}
+
+// WithListIncludeNonDownloadableFiles ...
+func WithListIncludeNonDownloadableFiles(includeNonDownloadableFiles bool) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.IncludeNonDownloadableFiles = includeNonDownloadableFiles
+	}
+}
+
+// WithListLimit sets the maximum number of entries returned on each page
+func WithListLimit(limit int) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.Limit = limit
+	}
+}
+
+// WithListSharedLink lists the content of a shared link to a folder, with the password of the link when it has one.
+// the path of the listing is relative to the shared folder
+func WithListSharedLink(url, password string) ListFolderOption {
+	return func(request *listFolderRequest) {
+		request.SharedLink = &sharedLinkArg{
+			URL:      url,
+			Password: password,
+		}
+	}
+}
+
+// GetMetadataOption ...
+type GetMetadataOption func(request *getMetadataRequest)
+
+// WithMetadataIncludeMediaInfo ...
+func WithMetadataIncludeMediaInfo(includeMediaInfo bool) GetMetadataOption {
+	return func(request *getMetadataRequest) {
+		request.IncludeMediaInfo = includeMediaInfo
+	}
+}
+
+// WithMetadataIncludeDeleted ...
+func WithMetadataIncludeDeleted(includeDeleted bool) GetMetadataOption {
+	return func(request *getMetadataRequest) {
+		request.IncludeDeleted = includeDeleted
+	}
+}
+
+// WithMetadataIncludeHasExplicitSharedMembers ...
+func WithMetadataIncludeHasExplicitSharedMembers(includeHasExplicitSharedMembers bool) GetMetadataOption {
+	return func(request *getMetadataRequest) {
+		request.IncludeHasExplicitSharedMembers = includeHasExplicitSharedMembers
+	}
+}
+
+// RelocationOption ...
+type RelocationOption func(request *relocationRequest)
+
+// WithRelocationAutoRename ...
+func WithRelocationAutoRename(autoRename bool) RelocationOption {
+	return func(request *relocationRequest) {
+		request.AutoRename = autoRename
+	}
+}
+
+// WithRelocationAllowOwnershipTransfer ...
+func WithRelocationAllowOwnershipTransfer(allowOwnershipTransfer bool) RelocationOption {
+	return func(request *relocationRequest) {
+		request.AllowOwnershipTransfer = allowOwnershipTransfer
+	}
+}
+
+// WithRelocationAllowSharedFolder ...
+func WithRelocationAllowSharedFolder(allowSharedFolder bool) RelocationOption {
+	return func(request *relocationRequest) {
+		request.AllowSharedFolder = allowSharedFolder
+	}
+}
+
+// CreateFolderBatchOption ...
+type CreateFolderBatchOption func(request *createFolderBatchRequest)
+
+// WithCreateFolderBatchAutoRename ...
+func WithCreateFolderBatchAutoRename(autoRename bool) CreateFolderBatchOption {
+	return func(request *createFolderBatchRequest) {
+		request.AutoRename = autoRename
+	}
+}
+
+// WithCreateFolderBatchForceAsync ...
+func WithCreateFolderBatchForceAsync(forceAsync bool) CreateFolderBatchOption {
+	return func(request *createFolderBatchRequest) {
+		request.ForceAsync = forceAsync
+	}
+}
+
+// SearchOption ...
+type SearchOption func(request *searchRequest)
+
+// WithSearchPath limits the search to the given folder
+func WithSearchPath(path string) SearchOption {
+	return func(request *searchRequest) {
+		request.Options.Path = path
+	}
+}
+
+// WithSearchMaxResults sets the maximum number of matches returned on each page
+func WithSearchMaxResults(maxResults int) SearchOption {
+	return func(request *searchRequest) {
+		request.Options.MaxResults = maxResults
+	}
+}
+
+// WithSearchFilenameOnly searches only on the file names, instead of the names and content
+func WithSearchFilenameOnly(filenameOnly bool) SearchOption {
+	return func(request *searchRequest) {
+		request.Options.FilenameOnly = filenameOnly
+	}
+}
+
+// WithSearchDeleted searches the deleted files, instead of the active ones
+func WithSearchDeleted(deleted bool) SearchOption {
+	return func(request *searchRequest) {
+		request.Options.FileStatus = tag{Tag: searchFileStatusActive}
+		if deleted {
+			request.Options.FileStatus = tag{Tag: searchFileStatusDeleted}
+		}
+	}
+}
+
+// WithSearchFileExtensions ...
+func WithSearchFileExtensions(extensions ...string) SearchOption {
+	return func(request *searchRequest) {
+		request.Options.FileExtensions = append(request.Options.FileExtensions, extensions...)
+	}
+}
+
+// WithSearchFileCategories ...
+func WithSearchFileCategories(categories ...SearchFileCategory) SearchOption {
+	return func(request *searchRequest) {
+		for _, category := range categories {
+			request.Options.FileCategories = append(request.Options.FileCategories, tag{Tag: string(category)})
+		}
+	}
+}
+
+// WithSearchIncludeHighlights ...
+func WithSearchIncludeHighlights(includeHighlights bool) SearchOption {
+	return func(request *searchRequest) {
+		request.MatchFieldOptions.IncludeHighlights = includeHighlights
+	}
+}
+
+// ListRevisionsOption ...
+type ListRevisionsOption func(request *listRevisionsRequest)
+
+// WithListRevisionsMode ...
+func WithListRevisionsMode(mode ListRevisionsMode) ListRevisionsOption {
+	return func(request *listRevisionsRequest) {
+		request.Mode = tag{Tag: string(mode)}
+	}
+}
+
+// UploadOption ...
+type UploadOption func(request *uploadFileRequest)
+
+// WithUploadModeAdd never overwrites an existing file
+func WithUploadModeAdd() UploadOption {
+	return func(request *uploadFileRequest) {
+		request.Mode = writeModeArg{Mode: writeModeAdd}
+	}
+}
+
+// WithUploadModeOverwrite always overwrites an existing file
+func WithUploadModeOverwrite() UploadOption {
+	return func(request *uploadFileRequest) {
+		request.Mode = writeModeArg{Mode: writeModeOverwrite}
+	}
+}
+
+// WithUploadModeUpdate overwrites the file only if its revision on the server is the given one.
+// it disables autorename, so an outdated revision fails with an error matching ErrConflict. the revision is required,
+// so the upload fails before any request without it
+func WithUploadModeUpdate(rev string) UploadOption {
+	return func(request *uploadFileRequest) {
+		request.Mode = writeModeArg{Mode: writeModeUpdate, Rev: rev}
+		request.AutoRename = false
+	}
+}
+
+// WithUploadAutoRename ...
+func WithUploadAutoRename(autoRename bool) UploadOption {
+	return func(request *uploadFileRequest) {
+		request.AutoRename = autoRename
+	}
+}
+
+// WithUploadMute ...
+func WithUploadMute(mute bool) UploadOption {
+	return func(request *uploadFileRequest) {
+		request.Mute = mute
+	}
+}
+
+// WithUploadStrictConflict ...
+func WithUploadStrictConflict(strictConflict bool) UploadOption {
+	return func(request *uploadFileRequest) {
+		request.StrictConflict = strictConflict
+	}
+}
+
+// WithUploadClientModified ...
+func WithUploadClientModified(clientModified time.Time) UploadOption {
+	return func(request *uploadFileRequest) {
+		// the api only accepts the modification time in seconds
+		clientModified = clientModified.UTC().Truncate(time.Second)
+		request.ClientModified = &clientModified
+	}
+}
+
+// SharedLinkOption ...
+type SharedLinkOption func(settings *sharedLinkSettings)
+
+// WithSharedLinkExpires ...
+func WithSharedLinkExpires(expires time.Time) SharedLinkOption {
+	return func(settings *sharedLinkSettings) {
+		// the api only accepts the expiration time in seconds
+		expires = expires.UTC().Truncate(time.Second)
+		settings.Expires = &expires
+	}
+}
+
+// WithSharedLinkPassword protects the shared link with a password
+func WithSharedLinkPassword(password string) SharedLinkOption {
+	return func(settings *sharedLinkSettings) {
+		requirePassword := password != ""
+		settings.RequirePassword = &requirePassword
+		settings.LinkPassword = password
+	}
+}
+
+// WithSharedLinkAudience ...
+func WithSharedLinkAudience(audience LinkAudience) SharedLinkOption {
+	return func(settings *sharedLinkSettings) {
+		settings.Audience = &tag{Tag: string(audience)}
+	}
+}
+
+// WithSharedLinkAccess ...
+func WithSharedLinkAccess(access LinkAccessLevel) SharedLinkOption {
+	return func(settings *sharedLinkSettings) {
+		settings.Access = &tag{Tag: string(access)}
+	}
+}
+
+// WithSharedLinkAllowDownload ...
+func WithSharedLinkAllowDownload(allowDownload bool) SharedLinkOption {
+	return func(settings *sharedLinkSettings) {
+		settings.AllowDownload = &allowDownload
+	}
+}
+
+// ShareFolderOption ...
+type ShareFolderOption func(request *shareFolderRequest)
+
+// WithShareFolderForceAsync ...
+func WithShareFolderForceAsync(forceAsync bool) ShareFolderOption {
+	return func(request *shareFolderRequest) {
+		request.ForceAsync = forceAsync
+	}
+}
+
+// WithShareFolderAclUpdatePolicy sets who can add and remove members, owner or editors
+func WithShareFolderAclUpdatePolicy(policy string) ShareFolderOption {
+	return func(request *shareFolderRequest) {
+		request.AclUpdatePolicy = &tag{Tag: policy}
+	}
+}
+
+// WithShareFolderMemberPolicy sets who can be a member, team or anyone
+func WithShareFolderMemberPolicy(policy string) ShareFolderOption {
+	return func(request *shareFolderRequest) {
+		request.MemberPolicy = &tag{Tag: policy}
+	}
+}
+
+// WithShareFolderSharedLinkPolicy sets who can access the shared links, anyone, team or members
+func WithShareFolderSharedLinkPolicy(policy string) ShareFolderOption {
+	return func(request *shareFolderRequest) {
+		request.SharedLinkPolicy = &tag{Tag: policy}
+	}
+}
@@ -2,9 +2,11 @@ package dropbox
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"time"
 )
 
 func GetEnv() string {
@@ -88,3 +90,16 @@ func WriteFile(fileName string, obj interface{}) error {
 
 	return nil
 }
+
+// wait waits for the duration, returning false if the context is cancelled before
+func wait(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}